@@ -5,11 +5,19 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/twadelij/cryptojackal/internal/api"
+	"github.com/twadelij/cryptojackal/internal/backtest"
 	"github.com/twadelij/cryptojackal/internal/config"
 	"github.com/twadelij/cryptojackal/internal/discovery"
+	"github.com/twadelij/cryptojackal/internal/events"
+	"github.com/twadelij/cryptojackal/internal/halt"
+	"github.com/twadelij/cryptojackal/internal/marketdata"
 	"github.com/twadelij/cryptojackal/internal/paper"
+	"github.com/twadelij/cryptojackal/internal/quotes"
+	"github.com/twadelij/cryptojackal/internal/signals"
+	"github.com/twadelij/cryptojackal/internal/storage"
 	"github.com/twadelij/cryptojackal/internal/trading"
 	"github.com/twadelij/cryptojackal/internal/wallet"
 	"go.uber.org/zap"
@@ -44,7 +52,7 @@ func main() {
 	// Initialize wallet (optional for paper trading)
 	var w *wallet.Wallet
 	if cfg.NodeURL != "" {
-		w, err = wallet.New(cfg.NodeURL, cfg.PrivateKey, cfg.ChainID, logger)
+		w, err = wallet.New(cfg.NodeURL, cfg.PrivateKey, cfg.ChainID, cfg.MaxGasPrice, cfg.PrivateRelayURL, cfg.PrivateRelayAuthKey, logger)
 		if err != nil {
 			logger.Warn("wallet initialization failed, continuing in paper mode only", zap.Error(err))
 		} else {
@@ -53,12 +61,76 @@ func main() {
 	}
 
 	// Initialize services
-	discoverySvc := discovery.NewService(cfg.CoinGeckoAPIKey, logger)
-	paperSvc := paper.NewService(cfg.InitialBalance, logger)
-	engine := trading.NewEngine(cfg, w, discoverySvc, paperSvc, logger)
+	quoteRouter := quotes.NewRouter(map[string][]quotes.Aggregator{
+		"ethereum": {
+			quotes.NewZeroXAggregator(cfg.ZeroXAPIKey, logger),
+			quotes.NewOneInchAggregator(cfg.OneInchAPIKey, cfg.ChainID, logger),
+		},
+		"bsc":     {quotes.NewOpenOceanAggregator("bsc", logger)},
+		"polygon": {quotes.NewOpenOceanAggregator("polygon", logger)},
+		"solana":  {quotes.NewJupiterAggregator(logger)},
+	}, logger)
+	marketSvc := marketdata.NewService(logger,
+		marketdata.NewDexScreenerSource(logger),
+		marketdata.NewCoinGeckoSource(cfg.CoinGeckoAPIKey, logger),
+	)
+	signalRegistry := signals.NewRegistry()
+	eventBus := events.NewBus(logger)
+	sourcesCfg := discovery.SourcesConfig{
+		GeckoTerminalEnabled:   cfg.GeckoTerminalEnabled,
+		GeckoTerminalRateLimit: cfg.GeckoTerminalRateLimit,
+		MoralisAPIKey:          cfg.MoralisAPIKey,
+		MoralisRateLimit:       cfg.MoralisRateLimit,
+		BirdeyeAPIKey:          cfg.BirdeyeAPIKey,
+		BirdeyeRateLimit:       cfg.BirdeyeRateLimit,
+		OnChainEnabled:         cfg.OnChainPriceEnabled,
+		PriceZScoreThreshold:   cfg.PriceZScoreThreshold,
+		PriceDispersionWarnPct: cfg.PriceDispersionWarnPct,
+	}
+	haltMgr := halt.NewManager(halt.Config{
+		MaxDrawdownPct:                 cfg.MaxDrawdownPct,
+		MaxConsecutiveLosses:           cfg.MaxConsecutiveLosses,
+		MaxLossPerWindow:               cfg.MaxLossPerWindow,
+		LossWindow:                     time.Duration(cfg.LossWindowMinutes) * time.Minute,
+		MaxConsecutiveProviderFailures: cfg.MaxConsecutiveProviderFailures,
+	}, logger)
+
+	var store storage.Store
+	sqliteStore, err := storage.NewSQLiteStore(cfg.StorageDBPath)
+	if err != nil {
+		logger.Warn("storage initialization failed, continuing without persistence", zap.Error(err))
+	} else {
+		store = sqliteStore
+		logger.Info("storage initialized", zap.String("path", cfg.StorageDBPath))
+	}
+
+	discoverySvc := discovery.NewService(cfg.CoinGeckoAPIKey, cfg.EtherscanAPIKey, cfg.NodeURL, sourcesCfg, quoteRouter, marketSvc, signalRegistry, haltMgr, store, logger)
+
+	var marginCfg paper.MarginConfig
+	if cfg.MarginEnabled {
+		marginCfg = paper.MarginConfig{
+			HourlyInterestRate: cfg.MarginHourlyInterestRate,
+			MaintenanceMargin:  cfg.MarginMaintenancePct,
+		}
+	}
+	priceLookup := func(ctx context.Context, address string) (float64, error) {
+		quote, err := discoverySvc.ReconcilePrice(ctx, "ethereum", address)
+		if err != nil {
+			return 0, err
+		}
+		return quote.MedianPrice, nil
+	}
+	var paperClock paper.Clock
+	if cfg.Debug {
+		paperClock = paper.NewMockClock(time.Now())
+		logger.Warn("debug API enabled: paper trading clock is a mock clock")
+	}
+	paperSvc := paper.NewService(cfg.InitialBalance, eventBus, haltMgr, marginCfg, priceLookup, paperClock, store, logger)
+	engine := trading.NewEngine(cfg, w, discoverySvc, paperSvc, haltMgr, eventBus, store, logger)
+	backtestEngine := backtest.NewEngine(signalRegistry, logger)
 
 	// Initialize API server
-	server := api.NewServer(cfg, engine, discoverySvc, paperSvc, logger)
+	server := api.NewServer(cfg, engine, discoverySvc, paperSvc, backtestEngine, haltMgr, eventBus, store, logger)
 
 	// Handle shutdown signals
 	sigChan := make(chan os.Signal, 1)
@@ -69,6 +141,9 @@ func main() {
 		logger.Info("shutdown signal received")
 		engine.Stop()
 		server.Shutdown(context.Background())
+		if store != nil {
+			store.Close()
+		}
 	}()
 
 	// Start the server
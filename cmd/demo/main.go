@@ -31,7 +31,7 @@ func main() {
 
 	// Demo discovery service
 	fmt.Println("📡 Testing Token Discovery...")
-	discSvc := discovery.NewService(cfg.CoinGeckoAPIKey, logger)
+	discSvc := discovery.NewService(cfg.CoinGeckoAPIKey, cfg.EtherscanAPIKey, cfg.NodeURL, discovery.SourcesConfig{}, nil, nil, nil, nil, nil, logger)
 	
 	trending, err := discSvc.GetTrendingTokens(ctx)
 	if err != nil {
@@ -49,7 +49,7 @@ func main() {
 
 	// Demo paper trading
 	fmt.Println("📝 Testing Paper Trading...")
-	paperSvc := paper.NewService(10.0, logger)
+	paperSvc := paper.NewService(10.0, nil, nil, paper.MarginConfig{}, nil, nil, nil, logger)
 	
 	portfolio := paperSvc.GetPortfolio()
 	fmt.Printf("   Initial balance: %.4f ETH\n", portfolio.ETHBalance)
@@ -62,7 +62,7 @@ func main() {
 		Price:   0.001,
 	}
 
-	trade, err := paperSvc.ExecuteTrade(ctx, testToken, models.TradeTypeBuy, 1000)
+	trade, err := paperSvc.ExecuteTrade(ctx, testToken, models.TradeTypeBuy, 1000, 1)
 	if err != nil {
 		fmt.Printf("   ❌ Trade failed: %v\n", err)
 	} else {
@@ -83,7 +83,7 @@ func main() {
 
 	// Demo opportunity finding
 	fmt.Println("🔍 Scanning for Opportunities...")
-	opportunities, err := discSvc.FindOpportunities(ctx, "ethereum", 10000)
+	opportunities, err := discSvc.FindOpportunities(ctx, "ethereum", 10000, cfg.TradeAmount)
 	if err != nil {
 		fmt.Printf("   ❌ Failed: %v\n", err)
 	} else {
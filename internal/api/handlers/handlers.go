@@ -1,12 +1,22 @@
 package handlers
 
 import (
+	"context"
+	"math/rand"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/twadelij/cryptojackal/internal/backtest"
 	"github.com/twadelij/cryptojackal/internal/discovery"
+	"github.com/twadelij/cryptojackal/internal/events"
+	"github.com/twadelij/cryptojackal/internal/halt"
+	"github.com/twadelij/cryptojackal/internal/marketdata"
 	"github.com/twadelij/cryptojackal/internal/models"
 	"github.com/twadelij/cryptojackal/internal/paper"
+	"github.com/twadelij/cryptojackal/internal/prices"
+	"github.com/twadelij/cryptojackal/internal/storage"
 	"github.com/twadelij/cryptojackal/internal/trading"
 	"go.uber.org/zap"
 )
@@ -16,15 +26,30 @@ type Handler struct {
 	engine    *trading.Engine
 	discovery *discovery.Service
 	paper     *paper.Service
+	backtest  *backtest.Engine
+	halt      *halt.Manager
+	events    *events.Bus
+	debug     bool
+	store     storage.Store
 	logger    *zap.Logger
 }
 
-// NewHandler creates a new handler
-func NewHandler(engine *trading.Engine, disc *discovery.Service, paperSvc *paper.Service, logger *zap.Logger) *Handler {
+// NewHandler creates a new handler. bus, backtestEngine and haltMgr are
+// optional; when nil, GET /ws and POST /backtest reject requests
+// respectively, and POST /bot/halt and /bot/resume report the breaker as
+// unconfigured. debug gates the /api/debug/* test harness; it should only
+// ever be true outside production. store is optional; when nil,
+// GetStoredTradeHistory falls back to in-memory paper trade history.
+func NewHandler(engine *trading.Engine, disc *discovery.Service, paperSvc *paper.Service, backtestEngine *backtest.Engine, haltMgr *halt.Manager, bus *events.Bus, debug bool, store storage.Store, logger *zap.Logger) *Handler {
 	return &Handler{
 		engine:    engine,
 		discovery: disc,
 		paper:     paperSvc,
+		backtest:  backtestEngine,
+		halt:      haltMgr,
+		events:    bus,
+		debug:     debug,
+		store:     store,
 		logger:    logger,
 	}
 }
@@ -68,6 +93,37 @@ func (h *Handler) StopBot(c *gin.Context) {
 	c.JSON(http.StatusOK, Response{Success: true, Data: "Bot stopped"})
 }
 
+// HaltRequest is the request body for POST /api/bot/halt.
+type HaltRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// HaltBot manually trips the halt circuit breaker, rejecting every
+// subsequent trade until an operator calls ResumeBot.
+func (h *Handler) HaltBot(c *gin.Context) {
+	if h.halt == nil {
+		c.JSON(http.StatusServiceUnavailable, Response{Success: false, Error: "halt manager not configured"})
+		return
+	}
+	var req HaltRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{Success: false, Error: err.Error()})
+		return
+	}
+	h.halt.Halt(req.Reason)
+	c.JSON(http.StatusOK, Response{Success: true, Data: "Bot halted"})
+}
+
+// ResumeBot clears the halt circuit breaker, manual or automatic.
+func (h *Handler) ResumeBot(c *gin.Context) {
+	if h.halt == nil {
+		c.JSON(http.StatusServiceUnavailable, Response{Success: false, Error: "halt manager not configured"})
+		return
+	}
+	h.halt.Resume()
+	c.JSON(http.StatusOK, Response{Success: true, Data: "Bot resumed"})
+}
+
 // GetOpportunities returns current trading opportunities
 func (h *Handler) GetOpportunities(c *gin.Context) {
 	opportunities := h.engine.GetOpportunities()
@@ -78,6 +134,7 @@ func (h *Handler) GetOpportunities(c *gin.Context) {
 type ExecuteTradeRequest struct {
 	OpportunityID string  `json:"opportunity_id"`
 	Amount        float64 `json:"amount"`
+	Aggregator    string  `json:"aggregator,omitempty"`
 }
 
 // ExecuteTrade executes a trade
@@ -103,7 +160,7 @@ func (h *Handler) ExecuteTrade(c *gin.Context) {
 		return
 	}
 
-	trade, err := h.engine.ExecuteTrade(c.Request.Context(), *opp, req.Amount)
+	trade, err := h.engine.ExecuteTrade(c.Request.Context(), *opp, req.Amount, req.Aggregator)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, Response{Success: false, Error: err.Error()})
 		return
@@ -112,20 +169,137 @@ func (h *Handler) ExecuteTrade(c *gin.Context) {
 	c.JSON(http.StatusOK, Response{Success: true, Data: trade})
 }
 
+// ReplayRequest is the request body for POST /paper/replay.
+type ReplayRequest struct {
+	Path                string  `json:"path" binding:"required"`
+	ConfidenceThreshold float64 `json:"confidence_threshold,omitempty"`
+	TradeAmount         float64 `json:"trade_amount,omitempty"`
+	InitialBalance      float64 `json:"initial_balance,omitempty"`
+}
+
+// ReplayTrading replays opportunities recorded as JSON lines in the file
+// at Path through the engine's auto-execute logic against a sandboxed
+// paper portfolio, returning aggregate PnL and the per-trade ledger. It
+// never touches the live paper portfolio or trade history.
+func (h *Handler) ReplayTrading(c *gin.Context) {
+	var req ReplayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{Success: false, Error: err.Error()})
+		return
+	}
+
+	feed, err := trading.NewJSONLReplaySource(req.Path)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{Success: false, Error: err.Error()})
+		return
+	}
+	defer feed.Close()
+
+	result, err := h.engine.Replay(c.Request.Context(), feed, trading.ReplayOpts{
+		ConfidenceThreshold: req.ConfidenceThreshold,
+		TradeAmount:         req.TradeAmount,
+		InitialBalance:      req.InitialBalance,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{Success: false, Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{Success: true, Data: result})
+}
+
+// GetQuote returns the best quote across enabled aggregators for a swap.
+func (h *Handler) GetQuote(c *gin.Context) {
+	tokenIn := c.Query("in")
+	tokenOut := c.Query("out")
+	chain := c.DefaultQuery("chain", "ethereum")
+	amount, err := strconv.ParseFloat(c.Query("amount"), 64)
+	if err != nil || tokenIn == "" || tokenOut == "" {
+		c.JSON(http.StatusBadRequest, Response{Success: false, Error: "in, out and a numeric amount are required"})
+		return
+	}
+
+	quote, err := h.discovery.GetQuote(c.Request.Context(), chain, tokenIn, tokenOut, amount)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{Success: false, Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{Success: true, Data: quote})
+}
+
 // GetTradingHistory returns trading history
 func (h *Handler) GetTradingHistory(c *gin.Context) {
 	trades := h.paper.GetTradeHistory(50)
 	c.JSON(http.StatusOK, Response{Success: true, Data: trades})
 }
 
-// GetTrendingTokens returns trending tokens
+// GetStoredTradeHistory returns persisted trade history, optionally
+// narrowed by the "since" (RFC3339), "token" and "limit" query params.
+// When no store is configured, it falls back to in-memory paper trade
+// history, same as GetTradingHistory.
+func (h *Handler) GetStoredTradeHistory(c *gin.Context) {
+	if h.store == nil {
+		trades := h.paper.GetTradeHistory(50)
+		c.JSON(http.StatusOK, Response{Success: true, Data: trades})
+		return
+	}
+
+	filter := storage.TradeFilter{
+		TokenAddress: c.Query("token"),
+	}
+	if since := c.Query("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, Response{Success: false, Error: "invalid since: " + err.Error()})
+			return
+		}
+		filter.Since = t
+	}
+	if limit := c.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, Response{Success: false, Error: "invalid limit: " + err.Error()})
+			return
+		}
+		filter.Limit = n
+	}
+
+	trades, err := h.store.LoadTrades(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, Response{Success: true, Data: trades})
+}
+
+// GetTrendingTokens returns trending tokens along with a reconciled price
+// quote (median price, dispersion, per-source breakdown) for each token
+// that has an on-chain address to reconcile.
 func (h *Handler) GetTrendingTokens(c *gin.Context) {
 	tokens, err := h.discovery.GetTrendingTokens(c.Request.Context())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, Response{Success: false, Error: err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, Response{Success: true, Data: tokens})
+
+	priceQuotes := make(map[string]*prices.PriceQuote, len(tokens))
+	for _, token := range tokens {
+		if token.Address == "" {
+			continue
+		}
+		quote, err := h.discovery.ReconcilePrice(c.Request.Context(), "ethereum", token.Address)
+		if err != nil {
+			h.logger.Warn("price reconciliation failed", zap.String("address", token.Address), zap.Error(err))
+			continue
+		}
+		priceQuotes[token.Address] = quote
+	}
+
+	c.JSON(http.StatusOK, Response{Success: true, Data: gin.H{
+		"tokens": tokens,
+		"prices": priceQuotes,
+	}})
 }
 
 // GetNewTokens returns newly discovered tokens
@@ -147,7 +321,7 @@ func (h *Handler) AnalyzeToken(c *gin.Context) {
 		return
 	}
 
-	token, err := h.discovery.AnalyzeToken(c.Request.Context(), address)
+	token, report, priceQuote, err := h.discovery.AnalyzeToken(c.Request.Context(), address)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, Response{Success: false, Error: err.Error()})
 		return
@@ -157,7 +331,25 @@ func (h *Handler) AnalyzeToken(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, Response{Success: true, Data: token})
+	c.JSON(http.StatusOK, Response{Success: true, Data: gin.H{
+		"token":    token,
+		"security": report,
+		"price":    priceQuote,
+	}})
+}
+
+// GetTokenKlines returns historical OHLC candles for a CoinGecko token ID.
+func (h *Handler) GetTokenKlines(c *gin.Context) {
+	id := c.Param("id")
+	period := models.KlinePeriod(c.DefaultQuery("period", string(models.KlinePeriod30Days)))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "0"))
+
+	klines, err := h.discovery.GetKlineRecords(c.Request.Context(), id, period, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, Response{Success: true, Data: klines})
 }
 
 // GetPaperBalance returns paper trading balance
@@ -180,6 +372,7 @@ type PaperTradeRequest struct {
 	Price        float64 `json:"price" binding:"required"`
 	Amount       float64 `json:"amount" binding:"required"`
 	Type         string  `json:"type" binding:"required"` // "buy" or "sell"
+	Leverage     float64 `json:"leverage,omitempty"`      // >1 opens a margin loan; omitted/0 is a 1x cash trade
 }
 
 // ExecutePaperTrade executes a paper trade
@@ -204,7 +397,7 @@ func (h *Handler) ExecutePaperTrade(c *gin.Context) {
 		tradeType = models.TradeTypeSell
 	}
 
-	trade, err := h.paper.ExecuteTrade(c.Request.Context(), token, tradeType, req.Amount)
+	trade, err := h.paper.ExecuteTrade(c.Request.Context(), token, tradeType, req.Amount, req.Leverage)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, Response{Success: false, Error: err.Error()})
 		return
@@ -213,8 +406,313 @@ func (h *Handler) ExecutePaperTrade(c *gin.Context) {
 	c.JSON(http.StatusOK, Response{Success: true, Data: trade})
 }
 
-// GetMetrics returns trading metrics
+// BorrowRequest is the request body for POST /api/paper/margin/borrow.
+type BorrowRequest struct {
+	Amount float64 `json:"amount" binding:"required"`
+}
+
+// BorrowMargin opens a margin loan against the paper portfolio.
+func (h *Handler) BorrowMargin(c *gin.Context) {
+	var req BorrowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{Success: false, Error: err.Error()})
+		return
+	}
+
+	loan, err := h.paper.Borrow(req.Amount)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{Success: false, Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{Success: true, Data: loan})
+}
+
+// RepayRequest is the request body for POST /api/paper/margin/repay.
+type RepayRequest struct {
+	LoanID string  `json:"loan_id" binding:"required"`
+	Amount float64 `json:"amount" binding:"required"`
+}
+
+// RepayMargin pays down an outstanding margin loan.
+func (h *Handler) RepayMargin(c *gin.Context) {
+	var req RepayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{Success: false, Error: err.Error()})
+		return
+	}
+
+	loan, err := h.paper.Repay(req.LoanID, req.Amount)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{Success: false, Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{Success: true, Data: loan})
+}
+
+// GetMarginLoans returns all outstanding margin loans.
+func (h *Handler) GetMarginLoans(c *gin.Context) {
+	c.JSON(http.StatusOK, Response{Success: true, Data: h.paper.GetLoans()})
+}
+
+// GetMarginInterest returns the lifetime borrowing and liquidation record.
+func (h *Handler) GetMarginInterest(c *gin.Context) {
+	c.JSON(http.StatusOK, Response{Success: true, Data: h.paper.GetInterestSummary()})
+}
+
+// GetKlines returns the rolling OHLCV window for a token pair.
+func (h *Handler) GetKlines(c *gin.Context) {
+	pair := c.Param("pair")
+	chain := c.DefaultQuery("chain", "ethereum")
+	interval := marketdata.Interval(c.DefaultQuery("interval", string(marketdata.Interval5m)))
+
+	bars, err := h.discovery.GetKlines(c.Request.Context(), chain, pair, interval)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, Response{Success: true, Data: bars})
+}
+
+// GetSignals returns the current technical indicator snapshot for a token pair.
+func (h *Handler) GetSignals(c *gin.Context) {
+	pair := c.Param("pair")
+	chain := c.DefaultQuery("chain", "ethereum")
+	interval := marketdata.Interval(c.DefaultQuery("interval", string(marketdata.Interval5m)))
+
+	snap, err := h.discovery.GetSignals(c.Request.Context(), chain, pair, interval)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, Response{Success: true, Data: snap})
+}
+
+// GetMetrics returns trading metrics alongside the health of each price
+// provider backing discovery's fallback chain.
 func (h *Handler) GetMetrics(c *gin.Context) {
-	metrics := h.paper.GetMetrics()
-	c.JSON(http.StatusOK, Response{Success: true, Data: metrics})
+	c.JSON(http.StatusOK, Response{Success: true, Data: gin.H{
+		"trading":         h.paper.GetMetrics(),
+		"price_providers": h.discovery.ProviderHealth(),
+	}})
+}
+
+// BacktestSweepRequest asks for a parameter sweep instead of a single run.
+// Grid and Random may be combined; their candidates are simply pooled.
+type BacktestSweepRequest struct {
+	Grid   map[string][]float64 `json:"grid,omitempty"`
+	Random *struct {
+		Ranges  map[string][2]float64 `json:"ranges"`
+		Samples int                    `json:"samples"`
+	} `json:"random,omitempty"`
+}
+
+// BacktestRequest is the request body for POST /backtest.
+type BacktestRequest struct {
+	From     time.Time             `json:"from" binding:"required"`
+	To       time.Time             `json:"to" binding:"required"`
+	Chain    string                `json:"chain"`
+	Tokens   []string              `json:"tokens" binding:"required"`
+	Strategy string                `json:"strategy"`
+	Params   *backtest.Params      `json:"params,omitempty"`
+	Sweep    *BacktestSweepRequest `json:"sweep,omitempty"`
+}
+
+// Backtest replays historical bars for the requested tokens against
+// FindOpportunities' momentum/signal heuristics and returns per-trade
+// results plus aggregate metrics, or a leaderboard across a parameter
+// sweep when Sweep is set.
+func (h *Handler) Backtest(c *gin.Context) {
+	if h.backtest == nil {
+		c.JSON(http.StatusServiceUnavailable, Response{Success: false, Error: "backtest engine not configured"})
+		return
+	}
+
+	var req BacktestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{Success: false, Error: err.Error()})
+		return
+	}
+
+	chain := req.Chain
+	if chain == "" {
+		chain = "ethereum"
+	}
+
+	series := h.loadBacktestSeries(c.Request.Context(), chain, req.Tokens, req.From, req.To)
+	if len(series) == 0 {
+		c.JSON(http.StatusUnprocessableEntity, Response{Success: false, Error: "no historical data available for the requested tokens/range"})
+		return
+	}
+
+	base := backtest.DefaultParams()
+	if req.Params != nil {
+		base = *req.Params
+	}
+	balance := h.paper.GetPortfolio().ETHBalance
+
+	if req.Sweep == nil {
+		result, err := h.backtest.Run(c.Request.Context(), series, base, balance)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, Response{Success: false, Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, Response{Success: true, Data: result})
+		return
+	}
+
+	var candidates []backtest.Params
+	if req.Sweep.Grid != nil {
+		candidates = backtest.GridCandidates(base, req.Sweep.Grid)
+	}
+	if req.Sweep.Random != nil {
+		rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+		candidates = append(candidates, backtest.RandomCandidates(base, req.Sweep.Random.Ranges, req.Sweep.Random.Samples, rng)...)
+	}
+	if len(candidates) == 0 {
+		c.JSON(http.StatusBadRequest, Response{Success: false, Error: "sweep requires a grid and/or random spec"})
+		return
+	}
+
+	leaderboard, err := h.backtest.Sweep(c.Request.Context(), series, candidates, balance)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, Response{Success: true, Data: leaderboard})
+}
+
+// DebugTickRequest is the request body for POST /api/debug/tick.
+type DebugTickRequest struct {
+	Token  models.Token `json:"token" binding:"required"`
+	Price  float64      `json:"price" binding:"required"`
+	Volume float64      `json:"volume"`
+}
+
+// DebugTick marks an existing paper token balance to a new price,
+// liquidating its margin position if the tick breaches liquidation.
+func (h *Handler) DebugTick(c *gin.Context) {
+	if !h.debug {
+		c.JSON(http.StatusServiceUnavailable, Response{Success: false, Error: "debug API not enabled"})
+		return
+	}
+
+	var req DebugTickRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{Success: false, Error: err.Error()})
+		return
+	}
+
+	token := req.Token
+	token.Price = req.Price
+	h.paper.Tick(token, req.Volume)
+	c.JSON(http.StatusOK, Response{Success: true, Data: h.paper.GetPortfolio()})
+}
+
+// DebugScenarioEvent is a single scripted market tick, replayed after
+// advancing the fake clock by DelayMs.
+type DebugScenarioEvent struct {
+	DelayMs int          `json:"delay_ms"`
+	Token   models.Token `json:"token" binding:"required"`
+	Price   float64      `json:"price" binding:"required"`
+}
+
+// DebugScenarioRequest is the request body for POST /api/debug/scenario.
+type DebugScenarioRequest struct {
+	Events []DebugScenarioEvent `json:"events" binding:"required"`
+}
+
+// DebugScenario replays a scripted sequence of price ticks, advancing the
+// paper trading clock by each event's delay before applying it. This
+// requires the server to have been started with a mock clock (cfg.Debug);
+// it errors otherwise.
+func (h *Handler) DebugScenario(c *gin.Context) {
+	if !h.debug {
+		c.JSON(http.StatusServiceUnavailable, Response{Success: false, Error: "debug API not enabled"})
+		return
+	}
+
+	var req DebugScenarioRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{Success: false, Error: err.Error()})
+		return
+	}
+
+	for _, event := range req.Events {
+		if event.DelayMs > 0 {
+			if err := h.paper.AdvanceClock(time.Duration(event.DelayMs) * time.Millisecond); err != nil {
+				c.JSON(http.StatusBadRequest, Response{Success: false, Error: err.Error()})
+				return
+			}
+		}
+		token := event.Token
+		token.Price = event.Price
+		h.paper.Tick(token, token.Volume24h)
+	}
+
+	c.JSON(http.StatusOK, Response{Success: true, Data: h.paper.GetPortfolio()})
+}
+
+// DebugSeedRequest is the request body for POST /api/debug/seed.
+type DebugSeedRequest struct {
+	Token    models.Token `json:"token" binding:"required"`
+	Amount   float64      `json:"amount" binding:"required"`
+	AvgPrice float64      `json:"avg_price"`
+}
+
+// DebugSeed preloads a token balance into the paper portfolio without
+// going through ExecuteTrade, so a test scenario can start from a known
+// state.
+func (h *Handler) DebugSeed(c *gin.Context) {
+	if !h.debug {
+		c.JSON(http.StatusServiceUnavailable, Response{Success: false, Error: "debug API not enabled"})
+		return
+	}
+
+	var req DebugSeedRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{Success: false, Error: err.Error()})
+		return
+	}
+
+	avgPrice := req.AvgPrice
+	if avgPrice == 0 {
+		avgPrice = req.Token.Price
+	}
+	h.paper.Seed(req.Token, req.Amount, avgPrice)
+	c.JSON(http.StatusOK, Response{Success: true, Data: h.paper.GetPortfolio()})
+}
+
+// loadBacktestSeries fetches each token's rolling kline window and live
+// metadata, clipping bars to [from, to]. Tokens with no usable history
+// are skipped rather than failing the whole request.
+func (h *Handler) loadBacktestSeries(ctx context.Context, chain string, tokens []string, from, to time.Time) []backtest.TokenSeries {
+	series := make([]backtest.TokenSeries, 0, len(tokens))
+	for _, address := range tokens {
+		token, _, _, err := h.discovery.AnalyzeToken(ctx, address)
+		if err != nil || token == nil {
+			h.logger.Warn("backtest: skipping token with no metadata", zap.String("address", address), zap.Error(err))
+			continue
+		}
+
+		bars, err := h.discovery.GetKlines(ctx, chain, address, marketdata.Interval5m)
+		if err != nil {
+			h.logger.Warn("backtest: skipping token with no historical bars", zap.String("address", address), zap.Error(err))
+			continue
+		}
+
+		clipped := make([]marketdata.Bar, 0, len(bars))
+		for _, b := range bars {
+			if !b.OpenTime.Before(from) && !b.CloseTime.After(to) {
+				clipped = append(clipped, b)
+			}
+		}
+		if len(clipped) == 0 {
+			continue
+		}
+
+		series = append(series, backtest.TokenSeries{Token: *token, Bars: clipped})
+	}
+	return series
 }
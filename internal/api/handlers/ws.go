@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/twadelij/cryptojackal/internal/events"
+	"go.uber.org/zap"
+)
+
+const (
+	wsWriteWait  = 10 * time.Second
+	wsPongWait   = 60 * time.Second
+	wsPingPeriod = (wsPongWait * 9) / 10
+	// wsMaxMessagesPerSecond bounds how many subscribe/unsubscribe
+	// messages a single connection can send before being disconnected.
+	wsMaxMessagesPerSecond = 10
+)
+
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// wsClientMessage is a subscription control message sent by the client.
+type wsClientMessage struct {
+	Action string `json:"action"` // "subscribe" or "unsubscribe"
+	Topic  string `json:"topic"`
+}
+
+// WebSocket upgrades the connection to a multiplexed event stream.
+// Clients send {"action":"subscribe","topic":"opportunities"} messages to
+// join a topic (one of events.TopicOpportunities, events.TopicTrades,
+// events.TopicPortfolio, or a dynamic "token:<address>"/"signals:<pair>"
+// topic) and receive Event JSON as messages arrive.
+func (h *Handler) WebSocket(c *gin.Context) {
+	if h.events == nil {
+		c.JSON(http.StatusServiceUnavailable, Response{Success: false, Error: "event bus not configured"})
+		return
+	}
+
+	conn, err := wsUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		h.logger.Warn("websocket upgrade failed", zap.Error(err))
+		return
+	}
+
+	client := newWSClient(conn, h.events, h.logger)
+	client.run()
+}
+
+// wsClient manages one WebSocket connection: its topic subscriptions, a
+// fan-in channel merging every subscribed topic's events, and the
+// heartbeat/rate-limit bookkeeping around it.
+type wsClient struct {
+	conn   *websocket.Conn
+	bus    *events.Bus
+	logger *zap.Logger
+
+	mu            sync.Mutex
+	unsubscribers map[string]func()
+	outbox        chan events.Event
+
+	rateMu             sync.Mutex
+	messagesThisSecond int
+	rateWindowStart    time.Time
+}
+
+func newWSClient(conn *websocket.Conn, bus *events.Bus, logger *zap.Logger) *wsClient {
+	return &wsClient{
+		conn:          conn,
+		bus:           bus,
+		logger:        logger,
+		unsubscribers: make(map[string]func()),
+		outbox:        make(chan events.Event, 128),
+	}
+}
+
+func (c *wsClient) run() {
+	defer c.close()
+
+	c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	c.conn.SetPongHandler(func(string) error {
+		c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		return nil
+	})
+
+	go c.writeLoop()
+	c.readLoop()
+}
+
+func (c *wsClient) readLoop() {
+	for {
+		var msg wsClientMessage
+		if err := c.conn.ReadJSON(&msg); err != nil {
+			return
+		}
+		if !c.allowMessage() {
+			c.logger.Warn("websocket client exceeded rate limit, disconnecting")
+			return
+		}
+
+		switch msg.Action {
+		case "subscribe":
+			c.subscribe(msg.Topic)
+		case "unsubscribe":
+			c.unsubscribe(msg.Topic)
+		}
+	}
+}
+
+func (c *wsClient) writeLoop() {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-c.outbox:
+			if !ok {
+				return
+			}
+			c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := c.conn.WriteJSON(event); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// allowMessage applies a simple fixed-window rate limit to inbound
+// control messages so a misbehaving client can't spam subscribe churn.
+func (c *wsClient) allowMessage() bool {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+
+	if time.Since(c.rateWindowStart) > time.Second {
+		c.rateWindowStart = time.Now()
+		c.messagesThisSecond = 0
+	}
+	c.messagesThisSecond++
+	return c.messagesThisSecond <= wsMaxMessagesPerSecond
+}
+
+func (c *wsClient) subscribe(topic string) {
+	if topic == "" {
+		return
+	}
+
+	c.mu.Lock()
+	if _, ok := c.unsubscribers[topic]; ok {
+		c.mu.Unlock()
+		return
+	}
+	c.mu.Unlock()
+
+	ch, unsubscribe := c.bus.Subscribe(topic)
+
+	c.mu.Lock()
+	c.unsubscribers[topic] = unsubscribe
+	c.mu.Unlock()
+
+	go func() {
+		for event := range ch {
+			select {
+			case c.outbox <- event:
+			default:
+				c.logger.Warn("websocket client outbox full, dropping event", zap.String("topic", topic))
+			}
+		}
+	}()
+}
+
+func (c *wsClient) unsubscribe(topic string) {
+	c.mu.Lock()
+	unsubscribe, ok := c.unsubscribers[topic]
+	delete(c.unsubscribers, topic)
+	c.mu.Unlock()
+
+	if ok {
+		unsubscribe()
+	}
+}
+
+func (c *wsClient) close() {
+	c.mu.Lock()
+	for _, unsubscribe := range c.unsubscribers {
+		unsubscribe()
+	}
+	c.unsubscribers = nil
+	c.mu.Unlock()
+
+	close(c.outbox)
+	c.conn.Close()
+}
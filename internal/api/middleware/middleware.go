@@ -95,37 +95,3 @@ func JWTAuth(secret string) gin.HandlerFunc {
 		c.Next()
 	}
 }
-
-// RateLimit returns a simple rate limiting middleware
-func RateLimit(requestsPerSecond int) gin.HandlerFunc {
-	limiter := make(chan struct{}, requestsPerSecond)
-	
-	// Refill the bucket
-	go func() {
-		ticker := time.NewTicker(time.Second / time.Duration(requestsPerSecond))
-		defer ticker.Stop()
-		for range ticker.C {
-			select {
-			case limiter <- struct{}{}:
-			default:
-			}
-		}
-	}()
-
-	// Initialize bucket
-	for i := 0; i < requestsPerSecond; i++ {
-		limiter <- struct{}{}
-	}
-
-	return func(c *gin.Context) {
-		select {
-		case <-limiter:
-			c.Next()
-		default:
-			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
-				"success": false,
-				"error":   "rate limit exceeded",
-			})
-		}
-	}
-}
@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// processStart anchors the monotonic clock RateLimit uses: time.Since
+// measures elapsed time using the runtime's monotonic reading, so bucket
+// refills are immune to wall-clock adjustments (NTP skew, DST, manual
+// clock changes) the way a time.Ticker driven off wall-clock time isn't.
+var processStart = time.Now()
+
+// monotonicNow returns nanoseconds elapsed since processStart.
+func monotonicNow() uint64 {
+	return uint64(time.Since(processStart))
+}
+
+// bucketIdleTimeout is how long a per-key bucket can go untouched before
+// the sweep evicts it, so a large or spoofable set of keys (e.g. rotating
+// source IPs) doesn't grow the bucket map without bound.
+const bucketIdleTimeout = 10 * time.Minute
+
+// bucket is one key's token bucket. Every field is only ever touched
+// while holding mu.
+type bucket struct {
+	mu         sync.Mutex
+	tokens     float64
+	lastRefill uint64 // nanoseconds since processStart
+}
+
+// RateLimitConfig configures RateLimit. PerSecond and Burst must both be
+// positive. KeyFunc partitions the limit; when nil, RateLimit limits by
+// client IP. Pass a KeyFunc reading the "user_id" context key JWTAuth
+// sets to limit per authenticated user instead, or a constant to limit an
+// entire route group as one shared bucket.
+type RateLimitConfig struct {
+	PerSecond float64
+	Burst     float64
+	KeyFunc   func(c *gin.Context) string
+}
+
+// RateLimit returns a gin middleware enforcing a per-key token bucket.
+// Each key starts with a full bucket of Burst tokens and refills at
+// PerSecond tokens/sec, computed from a monotonic clock on every request
+// rather than a background ticker. A request that finds its bucket empty
+// is rejected with 429 and a Retry-After header naming the wait in
+// seconds. Buckets live in a sync.Map so keys never contend on a shared
+// lock, and a background sweep evicts buckets idle for more than
+// bucketIdleTimeout.
+func RateLimit(cfg RateLimitConfig) gin.HandlerFunc {
+	keyFunc := cfg.KeyFunc
+	if keyFunc == nil {
+		keyFunc = func(c *gin.Context) string { return c.ClientIP() }
+	}
+
+	buckets := &sync.Map{}
+	go sweepBuckets(buckets)
+
+	return func(c *gin.Context) {
+		b := loadOrCreateBucket(buckets, keyFunc(c), cfg.Burst)
+
+		b.mu.Lock()
+		now := monotonicNow()
+		elapsed := float64(now-b.lastRefill) / 1e9
+		b.tokens += elapsed * cfg.PerSecond
+		if b.tokens > cfg.Burst {
+			b.tokens = cfg.Burst
+		}
+		b.lastRefill = now
+
+		if b.tokens < 1 {
+			retryAfter := (1 - b.tokens) / cfg.PerSecond
+			b.mu.Unlock()
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"success": false,
+				"error":   "rate limit exceeded",
+			})
+			return
+		}
+		b.tokens--
+		b.mu.Unlock()
+
+		c.Next()
+	}
+}
+
+// loadOrCreateBucket returns the existing bucket for key, or atomically
+// installs a freshly-full one if this is the first request seen for it.
+func loadOrCreateBucket(buckets *sync.Map, key string, burst float64) *bucket {
+	if v, ok := buckets.Load(key); ok {
+		return v.(*bucket)
+	}
+	fresh := &bucket{tokens: burst, lastRefill: monotonicNow()}
+	actual, _ := buckets.LoadOrStore(key, fresh)
+	return actual.(*bucket)
+}
+
+// sweepBuckets periodically evicts buckets idle for more than
+// bucketIdleTimeout. It runs for the lifetime of the process once
+// started.
+func sweepBuckets(buckets *sync.Map) {
+	ticker := time.NewTicker(bucketIdleTimeout)
+	defer ticker.Stop()
+	for range ticker.C {
+		buckets.Range(func(key, value interface{}) bool {
+			b := value.(*bucket)
+			b.mu.Lock()
+			idle := monotonicNow() - b.lastRefill
+			b.mu.Unlock()
+			if idle > uint64(bucketIdleTimeout) {
+				buckets.Delete(key)
+			}
+			return true
+		})
+	}
+}
@@ -11,9 +11,13 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/twadelij/cryptojackal/internal/api/handlers"
 	"github.com/twadelij/cryptojackal/internal/api/middleware"
+	"github.com/twadelij/cryptojackal/internal/backtest"
 	"github.com/twadelij/cryptojackal/internal/config"
 	"github.com/twadelij/cryptojackal/internal/discovery"
+	"github.com/twadelij/cryptojackal/internal/events"
+	"github.com/twadelij/cryptojackal/internal/halt"
 	"github.com/twadelij/cryptojackal/internal/paper"
+	"github.com/twadelij/cryptojackal/internal/storage"
 	"github.com/twadelij/cryptojackal/internal/trading"
 	"go.uber.org/zap"
 )
@@ -30,8 +34,12 @@ type Server struct {
 	logger  *zap.Logger
 }
 
-// NewServer creates a new API server
-func NewServer(cfg *config.Config, engine *trading.Engine, disc *discovery.Service, paperSvc *paper.Service, logger *zap.Logger) *Server {
+// NewServer creates a new API server. bus, backtestEngine and haltMgr
+// are optional; when nil, /ws and /backtest reject requests respectively,
+// and /bot/halt and /bot/resume report the breaker as unconfigured. store
+// is optional; when nil, /api/trading/history falls back to in-memory
+// paper trade history.
+func NewServer(cfg *config.Config, engine *trading.Engine, disc *discovery.Service, paperSvc *paper.Service, backtestEngine *backtest.Engine, haltMgr *halt.Manager, bus *events.Bus, store storage.Store, logger *zap.Logger) *Server {
 	// Set gin mode based on environment
 	if cfg.Environment == "production" {
 		gin.SetMode(gin.ReleaseMode)
@@ -51,7 +59,18 @@ func NewServer(cfg *config.Config, engine *trading.Engine, disc *discovery.Servi
 		MaxAge:           12 * time.Hour,
 	}))
 
-	handler := handlers.NewHandler(engine, disc, paperSvc, logger)
+	handler := handlers.NewHandler(engine, disc, paperSvc, backtestEngine, haltMgr, bus, cfg.Debug, store, logger)
+
+	limiter := middleware.RateLimit(middleware.RateLimitConfig{
+		PerSecond: cfg.RateLimitPerSecond,
+		Burst:     cfg.RateLimitBurst,
+	})
+
+	// Realtime event stream
+	router.GET("/ws", handler.WebSocket)
+
+	// Backtesting
+	router.POST("/backtest", handler.Backtest)
 
 	// Routes
 	api := router.Group("/api")
@@ -63,25 +82,45 @@ func NewServer(cfg *config.Config, engine *trading.Engine, disc *discovery.Servi
 		api.GET("/bot/status", handler.GetStatus)
 		api.POST("/bot/start", handler.StartBot)
 		api.POST("/bot/stop", handler.StopBot)
+		api.POST("/bot/halt", handler.HaltBot)
+		api.POST("/bot/resume", handler.ResumeBot)
 
 		// Trading
 		api.GET("/trading/opportunities", handler.GetOpportunities)
-		api.POST("/trading/execute", handler.ExecuteTrade)
-		api.GET("/trading/history", handler.GetTradingHistory)
+		api.POST("/trading/execute", limiter, handler.ExecuteTrade)
+		api.GET("/trading/history", handler.GetStoredTradeHistory)
 
 		// Discovery
-		api.GET("/discovery/trending", handler.GetTrendingTokens)
-		api.GET("/discovery/new", handler.GetNewTokens)
-		api.GET("/discovery/analyze/:address", handler.AnalyzeToken)
+		api.GET("/discovery/trending", limiter, handler.GetTrendingTokens)
+		api.GET("/discovery/new", limiter, handler.GetNewTokens)
+		api.GET("/discovery/analyze/:address", limiter, handler.AnalyzeToken)
+		api.GET("/discovery/klines/:id", limiter, handler.GetTokenKlines)
+
+		// Quotes
+		api.GET("/quote", handler.GetQuote)
+
+		// Market data / signals
+		api.GET("/klines/:pair", handler.GetKlines)
+		api.GET("/signals/:pair", handler.GetSignals)
 
 		// Paper trading
 		api.GET("/paper/balance", handler.GetPaperBalance)
 		api.POST("/paper/reset", handler.ResetPaperBalance)
 		api.POST("/paper/trade", handler.ExecutePaperTrade)
 		api.GET("/paper/history", handler.GetTradingHistory)
+		api.POST("/paper/margin/borrow", handler.BorrowMargin)
+		api.POST("/paper/margin/repay", handler.RepayMargin)
+		api.GET("/paper/margin/loans", handler.GetMarginLoans)
+		api.GET("/paper/margin/interests", handler.GetMarginInterest)
+		api.POST("/paper/replay", handler.ReplayTrading)
 
 		// Metrics
 		api.GET("/metrics", handler.GetMetrics)
+
+		// Debug (deterministic test harness; gated by cfg.Debug)
+		api.POST("/debug/tick", handler.DebugTick)
+		api.POST("/debug/scenario", handler.DebugScenario)
+		api.POST("/debug/seed", handler.DebugSeed)
 	}
 
 	// Serve embedded frontend
@@ -0,0 +1,267 @@
+// Package backtest replays historical OHLCV bars through the same
+// momentum/signal heuristics discovery.Service.FindOpportunities uses
+// live, so strategy thresholds can be tuned offline before they're
+// deployed against real liquidity.
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/twadelij/cryptojackal/internal/marketdata"
+	"github.com/twadelij/cryptojackal/internal/models"
+	"github.com/twadelij/cryptojackal/internal/signals"
+	"go.uber.org/zap"
+)
+
+// Params are the strategy thresholds a backtest run can tune; they mirror
+// the values currently hard-coded in discovery.Service.FindOpportunities,
+// passesSignals and the 0.6 auto-execute confidence check in
+// trading.Engine.scan.
+type Params struct {
+	MinLiquidity      float64 `json:"min_liquidity"`
+	MinPriceChangePct float64 `json:"min_price_change_pct"`
+	RSILow            float64 `json:"rsi_low"`
+	RSIHigh           float64 `json:"rsi_high"`
+	MinConfidence     float64 `json:"min_confidence"`
+	TradeAmount       float64 `json:"trade_amount"`
+}
+
+// DefaultParams mirrors the thresholds currently hard-coded live.
+func DefaultParams() Params {
+	return Params{
+		MinLiquidity:      10000,
+		MinPriceChangePct: 10,
+		RSILow:            30,
+		RSIHigh:           55,
+		MinConfidence:     0.6,
+		TradeAmount:       0.1,
+	}
+}
+
+// priceChangeWindow is how far back a trailing "24h" price change is
+// measured, approximating the live Token.PriceChange24h field from bars
+// alone.
+const priceChangeWindow = 24 * time.Hour
+
+// TokenSeries is one token's historical OHLCV bars plus its metadata.
+// Liquidity is treated as static for the run, the same way a single
+// FindOpportunities scan sees one liquidity snapshot per token.
+type TokenSeries struct {
+	Token models.Token
+	Bars  []marketdata.Bar
+}
+
+// TradeResult is one simulated entry/exit round trip.
+type TradeResult struct {
+	TokenSymbol   string        `json:"token_symbol"`
+	EntryTime     time.Time     `json:"entry_time"`
+	ExitTime      time.Time     `json:"exit_time"`
+	EntryPrice    float64       `json:"entry_price"`
+	ExitPrice     float64       `json:"exit_price"`
+	ProfitLoss    float64       `json:"profit_loss"`
+	ProfitLossPct float64       `json:"profit_loss_pct"`
+	HoldingTime   time.Duration `json:"holding_time"`
+	ExitReason    string        `json:"exit_reason"` // "take_profit", "stop_loss" or "end_of_data"
+}
+
+// Result is the outcome of a single backtest run.
+type Result struct {
+	Params         Params        `json:"params"`
+	Trades         []TradeResult `json:"trades"`
+	Sharpe         float64       `json:"sharpe"`
+	Sortino        float64       `json:"sortino"`
+	MaxDrawdownPct float64       `json:"max_drawdown_pct"`
+	WinRate        float64       `json:"win_rate"`
+	ProfitFactor   float64       `json:"profit_factor"`
+	AvgHoldingTime time.Duration `json:"avg_holding_time"`
+	Exposure       float64       `json:"exposure"`
+	NetProfitLoss  float64       `json:"net_profit_loss"`
+}
+
+// Engine replays historical bars through the signal registry to simulate
+// FindOpportunities' momentum/signal pipeline offline, without touching
+// any live discovery or trading state.
+type Engine struct {
+	signals *signals.Registry
+	logger  *zap.Logger
+}
+
+// NewEngine creates a backtest Engine. signalRegistry supplies the same
+// indicator set used live; it's currently unused by replay (which drives
+// its own per-token indicator state so each token's series can be scored
+// independently) but is kept so custom indicators registered for live
+// trading stay available to future strategies.
+func NewEngine(signalRegistry *signals.Registry, logger *zap.Logger) *Engine {
+	return &Engine{signals: signalRegistry, logger: logger}
+}
+
+// Run replays every series in turn (one open position per token at a
+// time) and returns the aggregate result across all of them.
+func (e *Engine) Run(ctx context.Context, series []TokenSeries, params Params, initialBalance float64) (*Result, error) {
+	if len(series) == 0 {
+		return nil, fmt.Errorf("backtest: no historical series supplied")
+	}
+
+	var allTrades []TradeResult
+	var windowStart, windowEnd time.Time
+
+	for _, s := range series {
+		if len(s.Bars) == 0 {
+			e.logger.Debug("backtest: skipping token with no historical bars", zap.String("symbol", s.Token.Symbol))
+			continue
+		}
+		if windowStart.IsZero() || s.Bars[0].OpenTime.Before(windowStart) {
+			windowStart = s.Bars[0].OpenTime
+		}
+		if last := s.Bars[len(s.Bars)-1].CloseTime; last.After(windowEnd) {
+			windowEnd = last
+		}
+		allTrades = append(allTrades, e.replay(s, params)...)
+	}
+
+	// Each token's trades are appended in series order above (one token
+	// fully processed before the next), not interleaved by exit time, so
+	// sort into a single chronological equity curve before anything below
+	// walks it as one — maxDrawdown in particular assumes a time-ordered
+	// sequence.
+	sort.Slice(allTrades, func(i, j int) bool {
+		return allTrades[i].ExitTime.Before(allTrades[j].ExitTime)
+	})
+
+	rs := tradeReturns(allTrades)
+	result := &Result{
+		Params:         params,
+		Trades:         allTrades,
+		Sharpe:         sharpeRatio(rs),
+		Sortino:        sortinoRatio(rs),
+		MaxDrawdownPct: maxDrawdown(allTrades, initialBalance),
+		WinRate:        winRate(allTrades),
+		ProfitFactor:   profitFactor(allTrades),
+		AvgHoldingTime: avgHoldingTime(allTrades),
+		Exposure:       exposure(allTrades, windowStart, windowEnd),
+	}
+	for _, t := range allTrades {
+		result.NetProfitLoss += t.ProfitLoss
+	}
+	return result, nil
+}
+
+// replay walks one token's bars in order, mirroring
+// discovery.Service.passesSignals/estimateOpportunity's entry logic and
+// the ATR-based stop-loss/take-profit levels FindOpportunities attaches
+// to each opportunity, but against historical bars instead of a live feed.
+func (e *Engine) replay(s TokenSeries, params Params) []TradeResult {
+	if s.Token.Liquidity < params.MinLiquidity {
+		return nil
+	}
+
+	ema20 := signals.NewEMA(20)
+	rsi14 := signals.NewRSI(14)
+	macd := signals.NewMACD()
+	atr14 := signals.NewATR(14)
+
+	var trades []TradeResult
+	var open *openPosition
+
+	for i, bar := range s.Bars {
+		ema := ema20.Update(bar.Close)
+		rsi := rsi14.Update(bar.Close)
+		_, _, macdHist := macd.Update(bar.Close)
+		atr := atr14.Update(bar)
+
+		if open != nil {
+			switch {
+			case bar.Low <= open.stopLoss:
+				trades = append(trades, open.close(bar.CloseTime, open.stopLoss, "stop_loss"))
+				open = nil
+			case bar.High >= open.takeProfit:
+				trades = append(trades, open.close(bar.CloseTime, open.takeProfit, "take_profit"))
+				open = nil
+			case i == len(s.Bars)-1:
+				trades = append(trades, open.close(bar.CloseTime, bar.Close, "end_of_data"))
+				open = nil
+			}
+			continue
+		}
+
+		priceChangePct := trailingPriceChangePct(s.Bars, i)
+		if priceChangePct <= params.MinPriceChangePct {
+			continue
+		}
+		if rsi <= params.RSILow || rsi >= params.RSIHigh {
+			continue
+		}
+		if macdHist <= 0 || bar.Close <= ema {
+			continue
+		}
+
+		confidence := 0.5
+		if priceChangePct > 20 {
+			confidence += 0.2
+		}
+		if bar.Volume > 100000 {
+			confidence += 0.1
+		}
+		if confidence < params.MinConfidence || atr <= 0 {
+			continue
+		}
+
+		open = &openPosition{
+			tokenSymbol: s.Token.Symbol,
+			entryTime:   bar.CloseTime,
+			entryPrice:  bar.Close,
+			amount:      params.TradeAmount,
+			stopLoss:    bar.Close - 1.5*atr,
+			takeProfit:  bar.Close + 3*atr,
+		}
+	}
+
+	return trades
+}
+
+// trailingPriceChangePct approximates Token.PriceChange24h from the bar
+// history itself: the percentage move from the last bar at or before
+// priceChangeWindow ago up to the bar at i.
+func trailingPriceChangePct(bars []marketdata.Bar, i int) float64 {
+	cutoff := bars[i].CloseTime.Add(-priceChangeWindow)
+	base := bars[0].Close
+	for _, b := range bars[:i+1] {
+		if b.OpenTime.After(cutoff) {
+			break
+		}
+		base = b.Close
+	}
+	if base == 0 {
+		return 0
+	}
+	return ((bars[i].Close - base) / base) * 100
+}
+
+// openPosition tracks a simulated in-flight entry.
+type openPosition struct {
+	tokenSymbol string
+	entryTime   time.Time
+	entryPrice  float64
+	amount      float64
+	stopLoss    float64
+	takeProfit  float64
+}
+
+func (p *openPosition) close(exitTime time.Time, exitPrice float64, reason string) TradeResult {
+	pnl := (exitPrice - p.entryPrice) * p.amount
+	pnlPct := ((exitPrice - p.entryPrice) / p.entryPrice) * 100
+	return TradeResult{
+		TokenSymbol:   p.tokenSymbol,
+		EntryTime:     p.entryTime,
+		ExitTime:      exitTime,
+		EntryPrice:    p.entryPrice,
+		ExitPrice:     exitPrice,
+		ProfitLoss:    pnl,
+		ProfitLossPct: pnlPct,
+		HoldingTime:   exitTime.Sub(p.entryTime),
+		ExitReason:    reason,
+	}
+}
@@ -0,0 +1,160 @@
+package backtest
+
+import (
+	"math"
+	"time"
+)
+
+// tradeReturns extracts each trade's percentage return in the order the
+// trades were recorded.
+func tradeReturns(trades []TradeResult) []float64 {
+	rs := make([]float64, len(trades))
+	for i, t := range trades {
+		rs[i] = t.ProfitLossPct
+	}
+	return rs
+}
+
+func mean(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / float64(len(xs))
+}
+
+func stdDev(xs []float64, m float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	var sumSq float64
+	for _, x := range xs {
+		sumSq += (x - m) * (x - m)
+	}
+	return math.Sqrt(sumSq / float64(len(xs)))
+}
+
+// sharpeRatio is the mean trade return over its standard deviation, left
+// unannualized since a backtest window has no fixed bar interval to
+// annualize against.
+func sharpeRatio(rs []float64) float64 {
+	if len(rs) == 0 {
+		return 0
+	}
+	m := mean(rs)
+	sd := stdDev(rs, m)
+	if sd == 0 {
+		return 0
+	}
+	return m / sd
+}
+
+// sortinoRatio is like sharpeRatio but only penalizes downside deviation.
+func sortinoRatio(rs []float64) float64 {
+	if len(rs) == 0 {
+		return 0
+	}
+	m := mean(rs)
+	var sumSq float64
+	var n int
+	for _, x := range rs {
+		if x < 0 {
+			sumSq += x * x
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	downside := math.Sqrt(sumSq / float64(n))
+	if downside == 0 {
+		return 0
+	}
+	return m / downside
+}
+
+// maxDrawdown walks the cumulative P&L curve, trades in the order they
+// were recorded, and returns the largest peak-to-trough percentage
+// decline.
+func maxDrawdown(trades []TradeResult, initialBalance float64) float64 {
+	if len(trades) == 0 {
+		return 0
+	}
+	equity := initialBalance
+	peak := initialBalance
+	var maxDD float64
+	for _, t := range trades {
+		equity += t.ProfitLoss
+		if equity > peak {
+			peak = equity
+		}
+		if peak > 0 {
+			if dd := (peak - equity) / peak; dd > maxDD {
+				maxDD = dd
+			}
+		}
+	}
+	return maxDD * 100
+}
+
+func winRate(trades []TradeResult) float64 {
+	if len(trades) == 0 {
+		return 0
+	}
+	var wins int
+	for _, t := range trades {
+		if t.ProfitLoss > 0 {
+			wins++
+		}
+	}
+	return float64(wins) / float64(len(trades))
+}
+
+func profitFactor(trades []TradeResult) float64 {
+	var grossProfit, grossLoss float64
+	for _, t := range trades {
+		if t.ProfitLoss > 0 {
+			grossProfit += t.ProfitLoss
+		} else {
+			grossLoss += -t.ProfitLoss
+		}
+	}
+	if grossLoss == 0 {
+		return grossProfit
+	}
+	return grossProfit / grossLoss
+}
+
+func avgHoldingTime(trades []TradeResult) time.Duration {
+	if len(trades) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, t := range trades {
+		total += t.HoldingTime
+	}
+	return total / time.Duration(len(trades))
+}
+
+// exposure returns the summed holding time of every trade as a fraction
+// of [windowStart, windowEnd], capped at 1. Per-token positions are
+// tracked independently (see Engine.replay), so overlapping positions
+// across different tokens are summed rather than deduplicated - this
+// approximates capital exposure, not wall-clock coverage.
+func exposure(trades []TradeResult, windowStart, windowEnd time.Time) float64 {
+	total := windowEnd.Sub(windowStart)
+	if total <= 0 {
+		return 0
+	}
+	var held time.Duration
+	for _, t := range trades {
+		held += t.HoldingTime
+	}
+	if frac := float64(held) / float64(total); frac < 1 {
+		return frac
+	}
+	return 1
+}
@@ -0,0 +1,118 @@
+package backtest
+
+import (
+	"context"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// Param name constants for GridCandidates/RandomCandidates, so callers
+// don't have to reach into Params via reflection.
+const (
+	ParamMinLiquidity      = "min_liquidity"
+	ParamMinPriceChangePct = "min_price_change_pct"
+	ParamRSILow            = "rsi_low"
+	ParamRSIHigh           = "rsi_high"
+	ParamMinConfidence     = "min_confidence"
+	ParamTradeAmount       = "trade_amount"
+)
+
+func withParam(p Params, name string, value float64) Params {
+	switch name {
+	case ParamMinLiquidity:
+		p.MinLiquidity = value
+	case ParamMinPriceChangePct:
+		p.MinPriceChangePct = value
+	case ParamRSILow:
+		p.RSILow = value
+	case ParamRSIHigh:
+		p.RSIHigh = value
+	case ParamMinConfidence:
+		p.MinConfidence = value
+	case ParamTradeAmount:
+		p.TradeAmount = value
+	}
+	return p
+}
+
+// GridCandidates expands a grid of named parameter value lists (see the
+// Param* constants) into every combination, starting from base for any
+// field left unspecified.
+func GridCandidates(base Params, grid map[string][]float64) []Params {
+	candidates := []Params{base}
+	for name, values := range grid {
+		var next []Params
+		for _, c := range candidates {
+			for _, v := range values {
+				next = append(next, withParam(c, name, v))
+			}
+		}
+		candidates = next
+	}
+	return candidates
+}
+
+// RandomCandidates draws n uniformly random samples from the given
+// [min,max] ranges (see the Param* constants), starting from base for any
+// field left unspecified.
+func RandomCandidates(base Params, ranges map[string][2]float64, n int, rng *rand.Rand) []Params {
+	candidates := make([]Params, n)
+	for i := 0; i < n; i++ {
+		c := base
+		for name, r := range ranges {
+			v := r[0] + rng.Float64()*(r[1]-r[0])
+			c = withParam(c, name, v)
+		}
+		candidates[i] = c
+	}
+	return candidates
+}
+
+// LeaderboardEntry pairs a candidate Params with its backtest Result.
+type LeaderboardEntry struct {
+	Params Params  `json:"params"`
+	Result *Result `json:"result"`
+}
+
+// Sweep runs one backtest per candidate, with at most runtime.NumCPU()
+// running at once, and returns a leaderboard sorted by descending Sharpe ratio.
+func (e *Engine) Sweep(ctx context.Context, series []TokenSeries, candidates []Params, initialBalance float64) ([]LeaderboardEntry, error) {
+	results := make([]LeaderboardEntry, len(candidates))
+	sem := make(chan struct{}, runtime.NumCPU())
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+
+	for i, p := range candidates {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, p Params) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			res, err := e.Run(ctx, series, p, initialBalance)
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+				return
+			}
+			results[i] = LeaderboardEntry{Params: p, Result: res}
+		}(i, p)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Result.Sharpe > results[j].Result.Sharpe
+	})
+	return results, nil
+}
@@ -28,6 +28,11 @@ type Config struct {
 	GasLimit          uint64
 	MaxGasPrice       uint64
 
+	// Private mempool (Flashbots-style) submission
+	PrivateRelayURL     string
+	PrivateRelayAuthKey string
+	UsePrivateMempool   bool
+
 	// Paper Trading
 	PaperTradingMode  bool
 	InitialBalance    float64
@@ -35,6 +40,9 @@ type Config struct {
 	// API Keys
 	CoinGeckoAPIKey   string
 	DexScreenerAPIKey string
+	EtherscanAPIKey   string
+	ZeroXAPIKey       string
+	OneInchAPIKey     string
 
 	// Notifications
 	TelegramBotToken  string
@@ -45,11 +53,46 @@ type Config struct {
 	JWTSecret         string
 	CORSOrigins       []string
 
+	// Price Sources
+	GeckoTerminalEnabled   bool
+	GeckoTerminalRateLimit int
+	MoralisAPIKey          string
+	MoralisRateLimit       int
+	BirdeyeAPIKey          string
+	BirdeyeRateLimit       int
+	OnChainPriceEnabled    bool
+	PriceZScoreThreshold   float64
+	PriceDispersionWarnPct float64
+
+	// Halt / Circuit Breaker
+	MaxDrawdownPct                 float64
+	MaxConsecutiveLosses           int
+	MaxLossPerWindow               float64
+	LossWindowMinutes              int
+	MaxConsecutiveProviderFailures int
+
+	// Margin Trading
+	MarginEnabled            bool
+	MarginHourlyInterestRate float64
+	MarginMaintenancePct     float64
+
 	// Redis
 	RedisURL          string
 
 	// Environment
 	Environment       string
+
+	// Debug enables the /api/debug/* deterministic test harness. Never
+	// enable this in production; it lets a caller seed balances and
+	// fast-forward the paper trading clock.
+	Debug bool
+
+	// Storage
+	StorageDBPath string
+
+	// Rate Limiting
+	RateLimitPerSecond float64
+	RateLimitBurst     float64
 }
 
 func Load() (*Config, error) {
@@ -75,6 +118,11 @@ func Load() (*Config, error) {
 		GasLimit:       uint64(getEnvInt("GAS_LIMIT", 300000)),
 		MaxGasPrice:    uint64(getEnvInt("MAX_GAS_PRICE_GWEI", 100)),
 
+		// Private mempool defaults
+		PrivateRelayURL:     getEnv("PRIVATE_RELAY_URL", ""),
+		PrivateRelayAuthKey: getEnv("PRIVATE_RELAY_AUTH_KEY", ""),
+		UsePrivateMempool:   getEnvBool("USE_PRIVATE_MEMPOOL", false),
+
 		// Paper trading defaults
 		PaperTradingMode: getEnvBool("PAPER_TRADING_MODE", true),
 		InitialBalance:   getEnvFloat("INITIAL_BALANCE", 10.0),
@@ -82,6 +130,9 @@ func Load() (*Config, error) {
 		// API Keys
 		CoinGeckoAPIKey:   getEnv("COINGECKO_API_KEY", ""),
 		DexScreenerAPIKey: getEnv("DEXSCREENER_API_KEY", ""),
+		EtherscanAPIKey:   getEnv("ETHERSCAN_API_KEY", ""),
+		ZeroXAPIKey:       getEnv("ZEROX_API_KEY", ""),
+		OneInchAPIKey:     getEnv("ONEINCH_API_KEY", ""),
 
 		// Notifications
 		TelegramBotToken:  getEnv("TELEGRAM_BOT_TOKEN", ""),
@@ -92,11 +143,44 @@ func Load() (*Config, error) {
 		JWTSecret:   getEnv("JWT_SECRET", "change-me-in-production"),
 		CORSOrigins: []string{getEnv("CORS_ORIGINS", "*")},
 
+		// Price Sources
+		GeckoTerminalEnabled:   getEnvBool("GECKOTERMINAL_ENABLED", true),
+		GeckoTerminalRateLimit: getEnvInt("GECKOTERMINAL_RATE_LIMIT", 30),
+		MoralisAPIKey:          getEnv("MORALIS_API_KEY", ""),
+		MoralisRateLimit:       getEnvInt("MORALIS_RATE_LIMIT", 25),
+		BirdeyeAPIKey:          getEnv("BIRDEYE_API_KEY", ""),
+		BirdeyeRateLimit:       getEnvInt("BIRDEYE_RATE_LIMIT", 15),
+		OnChainPriceEnabled:    getEnvBool("ONCHAIN_PRICE_ENABLED", true),
+		PriceZScoreThreshold:   getEnvFloat("PRICE_ZSCORE_THRESHOLD", 2.0),
+		PriceDispersionWarnPct: getEnvFloat("PRICE_DISPERSION_WARN_PCT", 0.05),
+
+		// Halt / Circuit Breaker
+		MaxDrawdownPct:                 getEnvFloat("MAX_DRAWDOWN_PCT", 25.0),
+		MaxConsecutiveLosses:           getEnvInt("MAX_CONSECUTIVE_LOSSES", 5),
+		MaxLossPerWindow:               getEnvFloat("MAX_LOSS_PER_WINDOW", 0),
+		LossWindowMinutes:              getEnvInt("LOSS_WINDOW_MINUTES", 60),
+		MaxConsecutiveProviderFailures: getEnvInt("MAX_CONSECUTIVE_PROVIDER_FAILURES", 5),
+
+		// Margin Trading
+		MarginEnabled:            getEnvBool("MARGIN_ENABLED", false),
+		MarginHourlyInterestRate: getEnvFloat("MARGIN_HOURLY_INTEREST_RATE", 0.0001),
+		MarginMaintenancePct:     getEnvFloat("MARGIN_MAINTENANCE_PCT", 0.1),
+
 		// Redis
 		RedisURL: getEnv("REDIS_URL", "redis://localhost:6379"),
 
 		// Environment
 		Environment: getEnv("ENVIRONMENT", "development"),
+
+		// Debug
+		Debug: getEnvBool("DEBUG", false),
+
+		// Storage
+		StorageDBPath: getEnv("STORAGE_DB_PATH", "cryptojackal.db"),
+
+		// Rate Limiting
+		RateLimitPerSecond: getEnvFloat("RATE_LIMIT_PER_SECOND", 5.0),
+		RateLimitBurst:     getEnvFloat("RATE_LIMIT_BURST", 20.0),
 	}
 
 	return cfg, nil
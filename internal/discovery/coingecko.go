@@ -199,3 +199,62 @@ func (c *CoinGeckoClient) GetTokenByContract(ctx context.Context, platform, cont
 		DiscoveredAt:   time.Now(),
 	}, nil
 }
+
+// GetKlineRecords fetches historical OHLC candles for tokenID over the
+// requested period, capped at limit records (0 means no cap). CoinGecko's
+// /ohlc endpoint reports no per-candle volume, so Kline.Volume is always
+// zero for this source, and CloseTime is derived from the next candle's
+// OpenTime (or equal to OpenTime for the last candle) since the endpoint
+// doesn't report candle width either.
+func (c *CoinGeckoClient) GetKlineRecords(ctx context.Context, tokenID string, interval models.KlinePeriod, limit int) ([]models.Kline, error) {
+	url := fmt.Sprintf("%s/coins/%s/ohlc?vs_currency=usd&days=%s", coingeckoBaseURL, tokenID, interval)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.apiKey != "" {
+		req.Header.Set("x-cg-demo-api-key", c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch OHLC data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("CoinGecko API error: %d", resp.StatusCode)
+	}
+
+	var raw [][5]float64
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if limit > 0 && len(raw) > limit {
+		raw = raw[len(raw)-limit:]
+	}
+
+	klines := make([]models.Kline, 0, len(raw))
+	for i, candle := range raw {
+		openTime := time.UnixMilli(int64(candle[0]))
+		closeTime := openTime
+		if i+1 < len(raw) {
+			closeTime = time.UnixMilli(int64(raw[i+1][0]))
+		}
+		klines = append(klines, models.Kline{
+			OpenTime:  openTime,
+			Open:      candle[1],
+			High:      candle[2],
+			Low:       candle[3],
+			Close:     candle[4],
+			CloseTime: closeTime,
+			Interval:  interval,
+		})
+	}
+
+	c.logger.Info("fetched kline records", zap.String("token_id", tokenID), zap.Int("count", len(klines)))
+	return klines, nil
+}
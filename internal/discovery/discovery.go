@@ -2,19 +2,65 @@ package discovery
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/twadelij/cryptojackal/internal/marketdata"
 	"github.com/twadelij/cryptojackal/internal/models"
+	"github.com/twadelij/cryptojackal/internal/prices"
+	"github.com/twadelij/cryptojackal/internal/quotes"
+	"github.com/twadelij/cryptojackal/internal/security"
+	"github.com/twadelij/cryptojackal/internal/signals"
+	"github.com/twadelij/cryptojackal/internal/storage"
 	"go.uber.org/zap"
 )
 
+// klineLookback is how many bars are loaded per token before evaluating
+// indicators in FindOpportunities.
+const klineLookback = 50
+
+// SourcesConfig toggles and rate-limits the optional price sources fanned
+// out to by the reconciliation layer behind AnalyzeToken/ReconcilePrice.
+// CoinGecko and DexScreener are always included since discovery already
+// depends on them. GeckoTerminal is enabled by GeckoTerminalEnabled,
+// Moralis and Birdeye once their API key is supplied, and OnChain only
+// when OnChainEnabled is set and a node URL is supplied.
+type SourcesConfig struct {
+	GeckoTerminalEnabled   bool
+	GeckoTerminalRateLimit int
+	MoralisAPIKey          string
+	MoralisRateLimit       int
+	BirdeyeAPIKey          string
+	BirdeyeRateLimit       int
+	OnChainEnabled         bool
+	PriceZScoreThreshold   float64
+	PriceDispersionWarnPct float64
+}
+
+// HaltNotifier lets discovery report systemic price-provider failures to
+// an external circuit breaker (see internal/halt) without depending on
+// that package. *halt.Manager satisfies this interface.
+type HaltNotifier interface {
+	RecordProviderFailure()
+	RecordProviderSuccess()
+}
+
 // Service manages token discovery from multiple sources
 type Service struct {
 	coingecko    *CoinGeckoClient
 	dexscreener  *DexScreenerClient
+	providers    *FallbackProvider
+	security     security.SecurityAnalyzer
+	priceRecon   *prices.Reconciler
+	quotes       *quotes.Router
+	market       *marketdata.Service
+	signals      *signals.Registry
+	haltNotifier HaltNotifier
+	store        storage.Store
 	logger       *zap.Logger
-	
+
 	// Cache
 	mu           sync.RWMutex
 	trendingCache []models.Token
@@ -22,17 +68,160 @@ type Service struct {
 	cacheTTL     time.Duration
 }
 
-// NewService creates a new discovery service
-func NewService(coingeckoAPIKey string, logger *zap.Logger) *Service {
+// NewService creates a new discovery service. nodeURL and etherscanAPIKey
+// configure the security analyzer; both are optional and the analyzer
+// degrades gracefully (skipping on-chain or verification checks) when
+// left empty. sourcesCfg configures the additional price sources used for
+// reconciliation; zero-valued fields simply disable that source.
+// quoteRouter supplies real DEX aggregator quotes for opportunity
+// scoring; market and signalRegistry supply OHLCV history and technical
+// indicators. All three may be nil, in which case FindOpportunities falls
+// back to the momentum-only heuristic this used to be. haltNotifier is
+// optional; when set, it's told whenever the price-provider fallback
+// chain is exhausted or recovers, so a circuit breaker can auto-halt on
+// repeated provider failures. store is optional; when set, every token
+// with an address surfaced by GetTrendingTokens and GetNewTokens is upserted into it, best
+// effort, so discovered tokens survive a restart.
+func NewService(coingeckoAPIKey, etherscanAPIKey, nodeURL string, sourcesCfg SourcesConfig, quoteRouter *quotes.Router, market *marketdata.Service, signalRegistry *signals.Registry, haltNotifier HaltNotifier, store storage.Store, logger *zap.Logger) *Service {
+	cgClient := NewCoinGeckoClient(coingeckoAPIKey, logger)
+	dsClient := NewDexScreenerClient(logger)
+
+	priceSources := []prices.PriceSource{
+		&coingeckoPriceSource{client: cgClient},
+		&dexscreenerPriceSource{client: dsClient},
+	}
+	if sourcesCfg.GeckoTerminalEnabled {
+		priceSources = append(priceSources, prices.WithRateLimit(prices.NewGeckoTerminalSource(logger), sourcesCfg.GeckoTerminalRateLimit))
+	}
+	if sourcesCfg.MoralisAPIKey != "" {
+		priceSources = append(priceSources, prices.WithRateLimit(prices.NewMoralisSource(sourcesCfg.MoralisAPIKey, logger), sourcesCfg.MoralisRateLimit))
+	}
+	if sourcesCfg.BirdeyeAPIKey != "" {
+		priceSources = append(priceSources, prices.WithRateLimit(prices.NewBirdeyeSource(sourcesCfg.BirdeyeAPIKey, logger), sourcesCfg.BirdeyeRateLimit))
+	}
+	if sourcesCfg.OnChainEnabled && nodeURL != "" {
+		if client, err := ethclient.Dial(nodeURL); err != nil {
+			logger.Warn("discovery: on-chain price source disabled, failed to dial node", zap.Error(err))
+		} else {
+			priceSources = append(priceSources, prices.NewOnChainReader(client))
+		}
+	}
+
+	zScoreThreshold := sourcesCfg.PriceZScoreThreshold
+	if zScoreThreshold == 0 {
+		zScoreThreshold = 2.0
+	}
+	dispersionWarn := sourcesCfg.PriceDispersionWarnPct
+	if dispersionWarn == 0 {
+		dispersionWarn = 0.05
+	}
+
+	providers := NewFallbackProvider([]PriceProvider{
+		&coinGeckoProvider{client: cgClient},
+		&dexScreenerProvider{client: dsClient},
+	}, logger)
+
 	return &Service{
-		coingecko:   NewCoinGeckoClient(coingeckoAPIKey, logger),
-		dexscreener: NewDexScreenerClient(logger),
-		logger:      logger,
-		cacheTTL:    5 * time.Minute,
+		coingecko:    cgClient,
+		dexscreener:  dsClient,
+		providers:    providers,
+		security:     security.NewAnalyzer(nodeURL, etherscanAPIKey, logger),
+		priceRecon:   prices.NewReconciler(priceSources, zScoreThreshold, dispersionWarn, logger),
+		quotes:       quoteRouter,
+		market:       market,
+		signals:      signalRegistry,
+		haltNotifier: haltNotifier,
+		store:        store,
+		logger:       logger,
+		cacheTTL:     5 * time.Minute,
 	}
 }
 
-// GetTrendingTokens returns trending tokens (cached)
+// upsertTokens best-effort persists every token to the store, if one is
+// configured, logging and otherwise ignoring failures.
+func (s *Service) upsertTokens(ctx context.Context, tokens []models.Token) {
+	if s.store == nil {
+		return
+	}
+	for _, token := range tokens {
+		if token.Address == "" {
+			continue
+		}
+		if err := s.store.UpsertToken(ctx, token); err != nil {
+			s.logger.Warn("discovery: failed to persist token", zap.String("address", token.Address), zap.Error(err))
+		}
+	}
+}
+
+// ReconcilePrice fans out to every configured price source and returns the
+// median-reconciled quote plus each source's contribution, excluding
+// sources whose deviation from the median exceeds the configured
+// Z-score threshold.
+func (s *Service) ReconcilePrice(ctx context.Context, chain, address string) (*prices.PriceQuote, error) {
+	return s.priceRecon.Reconcile(ctx, chain, address)
+}
+
+// ProviderHealth returns the current success/failure record for each
+// configured price provider, for surfacing on /api/metrics.
+func (s *Service) ProviderHealth() []ProviderHealth {
+	return s.providers.Health()
+}
+
+// GetKlines returns the rolling OHLCV window for a token, fetching
+// history first if the in-memory window is empty.
+func (s *Service) GetKlines(ctx context.Context, chain, address string, interval marketdata.Interval) ([]marketdata.Bar, error) {
+	if s.market == nil {
+		return nil, fmt.Errorf("no market data source configured")
+	}
+	if bars := s.market.Klines(chain, address, interval); len(bars) > 0 {
+		return bars, nil
+	}
+	return s.market.Load(ctx, chain, address, interval, klineLookback)
+}
+
+// GetKlineRecords fetches historical OHLC candles for a CoinGecko token ID
+// over the given period, for backtesting via paper.Service.ReplayKlines.
+func (s *Service) GetKlineRecords(ctx context.Context, tokenID string, interval models.KlinePeriod, limit int) ([]models.Kline, error) {
+	return s.coingecko.GetKlineRecords(ctx, tokenID, interval, limit)
+}
+
+// GetSignals evaluates the full indicator set over a token's recent klines.
+func (s *Service) GetSignals(ctx context.Context, chain, address string, interval marketdata.Interval) (*signals.Snapshot, error) {
+	if s.signals == nil {
+		return nil, fmt.Errorf("no signal registry configured")
+	}
+	bars, err := s.GetKlines(ctx, chain, address, interval)
+	if err != nil {
+		return nil, err
+	}
+	return s.signals.Evaluate(bars)
+}
+
+// GetQuote returns the best single-leg quote across the configured
+// aggregators for swapping amountIn of tokenIn into tokenOut on chain.
+func (s *Service) GetQuote(ctx context.Context, chain, tokenIn, tokenOut string, amountIn float64) (*quotes.Quote, error) {
+	if s.quotes == nil {
+		return nil, fmt.Errorf("no quote aggregators configured")
+	}
+	return s.quotes.Best(ctx, chain, tokenIn, tokenOut, amountIn)
+}
+
+// GetQuoteViaAggregator quotes a buy of tokenOut using the wrapped-native
+// token for chain, routed through a specific named aggregator rather than
+// the best-of-all result.
+func (s *Service) GetQuoteViaAggregator(ctx context.Context, chain, aggregatorName, tokenOut string, amountIn float64) (*quotes.Quote, error) {
+	if s.quotes == nil {
+		return nil, fmt.Errorf("no quote aggregators configured")
+	}
+	agg, err := s.quotes.ByName(chain, aggregatorName)
+	if err != nil {
+		return nil, err
+	}
+	return agg.Quote(ctx, quotes.NativeTokenAddress(chain), tokenOut, amountIn)
+}
+
+// GetTrendingTokens returns trending tokens (cached). Falls back through
+// every configured PriceProvider in order if the primary fails.
 func (s *Service) GetTrendingTokens(ctx context.Context) ([]models.Token, error) {
 	s.mu.RLock()
 	if time.Since(s.cacheTime) < s.cacheTTL && len(s.trendingCache) > 0 {
@@ -42,22 +231,43 @@ func (s *Service) GetTrendingTokens(ctx context.Context) ([]models.Token, error)
 	}
 	s.mu.RUnlock()
 
-	tokens, err := s.coingecko.GetTrendingTokens(ctx)
+	tokens, err := s.providers.GetTrendingTokens(ctx)
 	if err != nil {
+		if s.haltNotifier != nil {
+			s.haltNotifier.RecordProviderFailure()
+		}
 		return nil, err
 	}
+	if s.haltNotifier != nil {
+		s.haltNotifier.RecordProviderSuccess()
+	}
 
 	s.mu.Lock()
 	s.trendingCache = tokens
 	s.cacheTime = time.Now()
 	s.mu.Unlock()
 
+	s.upsertTokens(ctx, tokens)
 	return tokens, nil
 }
 
 // GetNewTokens discovers new tokens from DexScreener
 func (s *Service) GetNewTokens(ctx context.Context, chain string) ([]models.Token, error) {
-	return s.dexscreener.GetNewPairs(ctx, chain)
+	tokens, err := s.dexscreener.GetNewPairs(ctx, chain)
+	if err != nil {
+		return nil, err
+	}
+	s.upsertTokens(ctx, tokens)
+	return tokens, nil
+}
+
+// QueryStoredTokens queries the persisted token history via the
+// configured store. It returns an error if no store is configured.
+func (s *Service) QueryStoredTokens(ctx context.Context, filter storage.TokenFilter) ([]models.Token, error) {
+	if s.store == nil {
+		return nil, fmt.Errorf("no token store configured")
+	}
+	return s.store.QueryTokens(ctx, filter)
 }
 
 // GetTopGainers returns top gaining tokens
@@ -65,61 +275,44 @@ func (s *Service) GetTopGainers(ctx context.Context, chain string, minLiquidity
 	return s.dexscreener.GetTopGainers(ctx, chain, minLiquidity)
 }
 
-// AnalyzeToken analyzes a specific token
-func (s *Service) AnalyzeToken(ctx context.Context, address string) (*models.Token, error) {
+// AnalyzeToken analyzes a specific token, running it through the security
+// analyzer and the price reconciler and attaching the resulting report and
+// reconciled quote alongside the token.
+func (s *Service) AnalyzeToken(ctx context.Context, address string) (*models.Token, *security.Report, *prices.PriceQuote, error) {
 	// Try DexScreener first
 	tokens, err := s.dexscreener.SearchToken(ctx, address)
+	var token *models.Token
 	if err == nil && len(tokens) > 0 {
-		token := tokens[0]
-		// Calculate a basic security score
-		token.SecurityScore = s.calculateSecurityScore(&token)
-		return &token, nil
-	}
-
-	// Fallback to CoinGecko
-	token, err := s.coingecko.GetTokenByContract(ctx, "ethereum", address)
-	if err != nil {
-		return nil, err
-	}
-	if token != nil {
-		token.SecurityScore = s.calculateSecurityScore(token)
-	}
-	return token, nil
-}
-
-// calculateSecurityScore calculates a basic security score for a token
-func (s *Service) calculateSecurityScore(token *models.Token) float64 {
-	score := 0.5 // Base score
-
-	// Higher liquidity = higher score
-	if token.Liquidity > 100000 {
-		score += 0.2
-	} else if token.Liquidity > 50000 {
-		score += 0.1
+		token = &tokens[0]
+	} else {
+		// Fallback to CoinGecko
+		token, err = s.coingecko.GetTokenByContract(ctx, "ethereum", address)
+		if err != nil {
+			return nil, nil, nil, err
+		}
 	}
-
-	// Higher volume = higher score
-	if token.Volume24h > 100000 {
-		score += 0.15
-	} else if token.Volume24h > 50000 {
-		score += 0.1
+	if token == nil {
+		return nil, nil, nil, nil
 	}
 
-	// Market cap presence is good
-	if token.MarketCap > 0 {
-		score += 0.1
+	report, err := s.security.Analyze(ctx, "ethereum", address)
+	if err != nil {
+		return token, nil, nil, err
 	}
+	token.SecurityScore = report.Score
 
-	// Cap at 1.0
-	if score > 1.0 {
-		score = 1.0
+	priceQuote, err := s.ReconcilePrice(ctx, "ethereum", address)
+	if err != nil {
+		s.logger.Warn("price reconciliation failed", zap.String("address", address), zap.Error(err))
+		return token, report, nil, nil
 	}
 
-	return score
+	return token, report, priceQuote, nil
 }
 
-// FindOpportunities scans for trading opportunities
-func (s *Service) FindOpportunities(ctx context.Context, chain string, minLiquidity float64) ([]models.TradingOpportunity, error) {
+// FindOpportunities scans for trading opportunities. tradeAmount sizes the
+// round-trip quote used to estimate real slippage and net profit.
+func (s *Service) FindOpportunities(ctx context.Context, chain string, minLiquidity, tradeAmount float64) ([]models.TradingOpportunity, error) {
 	tokens, err := s.GetTopGainers(ctx, chain, minLiquidity)
 	if err != nil {
 		return nil, err
@@ -129,21 +322,37 @@ func (s *Service) FindOpportunities(ctx context.Context, chain string, minLiquid
 	for _, token := range tokens {
 		// Simple opportunity detection based on momentum
 		if token.PriceChange24h > 10 && token.Liquidity > minLiquidity {
-			confidence := 0.5
-			if token.PriceChange24h > 20 {
-				confidence = 0.7
+			if token.Address != "" {
+				report, err := s.security.Analyze(ctx, chain, token.Address)
+				if err != nil {
+					s.logger.Warn("security analysis failed, skipping token", zap.String("address", token.Address), zap.Error(err))
+					continue
+				}
+				token.SecurityScore = report.Score
+				if report.FailsHardChecks() {
+					s.logger.Info("token filtered out by security screener",
+						zap.String("address", token.Address),
+						zap.Strings("reasons", report.Reasons),
+					)
+					continue
+				}
+			}
+
+			snap, ok := s.passesSignals(ctx, chain, token)
+			if !ok {
+				continue
 			}
-			if token.Volume24h > 100000 {
-				confidence += 0.1
+
+			expectedProfit, priceImpact, confidence, ok := s.estimateOpportunity(ctx, chain, token, tradeAmount)
+			if !ok {
+				continue
 			}
 
-			opp := models.NewOpportunity(
-				token,
-				token.PriceChange24h * 0.1, // Expected 10% of current momentum
-				0.01, // 1% price impact estimate
-				confidence,
-				"momentum",
-			)
+			opp := models.NewOpportunity(token, expectedProfit, priceImpact, confidence, "momentum")
+			if snap != nil && snap.ATR14 > 0 {
+				opp.StopLoss = token.Price - 1.5*snap.ATR14
+				opp.TakeProfit = token.Price + 3*snap.ATR14
+			}
 			opportunities = append(opportunities, *opp)
 		}
 	}
@@ -151,3 +360,69 @@ func (s *Service) FindOpportunities(ctx context.Context, chain string, minLiquid
 	s.logger.Info("found trading opportunities", zap.Int("count", len(opportunities)))
 	return opportunities, nil
 }
+
+// passesSignals checks momentum against the built-in indicator set: RSI
+// bouncing out of oversold, a MACD histogram that's flipped positive, and
+// price trading above its EMA(20). When no market data/signal registry is
+// configured it's a no-op pass so the momentum-only heuristic still works.
+// It returns the evaluated snapshot (nil if skipped) so callers can derive
+// ATR-based stop-loss/take-profit levels from it.
+func (s *Service) passesSignals(ctx context.Context, chain string, token models.Token) (*signals.Snapshot, bool) {
+	if s.signals == nil || s.market == nil || token.Address == "" {
+		return nil, true
+	}
+
+	snap, err := s.GetSignals(ctx, chain, token.Address, marketdata.Interval5m)
+	if err != nil {
+		s.logger.Warn("signal evaluation failed, skipping token", zap.String("address", token.Address), zap.Error(err))
+		return nil, false
+	}
+
+	oversoldBounce := snap.RSI14 > 30 && snap.RSI14 < 55
+	macdTurnedPositive := snap.MACDHist > 0
+	aboveEMA := token.Price > snap.EMA20
+
+	if !oversoldBounce || !macdTurnedPositive || !aboveEMA {
+		return snap, false
+	}
+	return snap, true
+}
+
+// estimateOpportunity derives expected profit, price impact and
+// confidence for a candidate token. When a quote router is configured it
+// prices a real buy+sell round trip; otherwise it falls back to the
+// momentum-only heuristic this used to be.
+func (s *Service) estimateOpportunity(ctx context.Context, chain string, token models.Token, tradeAmount float64) (expectedProfit, priceImpact, confidence float64, ok bool) {
+	confidence = 0.5
+	if token.PriceChange24h > 20 {
+		confidence = 0.7
+	}
+	if token.Volume24h > 100000 {
+		confidence += 0.1
+	}
+
+	if s.quotes == nil || token.Address == "" {
+		return token.PriceChange24h * 0.1, 0.01, confidence, true
+	}
+
+	nativeIn := quotes.NativeTokenAddress(chain)
+	if nativeIn == "" || tradeAmount <= 0 {
+		return token.PriceChange24h * 0.1, 0.01, confidence, true
+	}
+
+	roundTrip, err := s.quotes.RoundTripQuote(ctx, chain, nativeIn, token.Address, tradeAmount)
+	if err != nil {
+		s.logger.Warn("round-trip quote failed, skipping opportunity", zap.String("address", token.Address), zap.Error(err))
+		return 0, 0, 0, false
+	}
+
+	netProfitPct := 0.0
+	if tradeAmount > 0 {
+		netProfitPct = ((roundTrip.NetAmountOut - tradeAmount) / tradeAmount) * 100
+	}
+	if netProfitPct <= 0 {
+		return 0, 0, 0, false
+	}
+
+	return netProfitPct, roundTrip.EffectiveSlippage, confidence, true
+}
@@ -0,0 +1,45 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/twadelij/cryptojackal/internal/prices"
+)
+
+// coingeckoPriceSource adapts CoinGeckoClient to prices.PriceSource.
+type coingeckoPriceSource struct {
+	client *CoinGeckoClient
+}
+
+func (s *coingeckoPriceSource) Name() string { return "coingecko" }
+
+func (s *coingeckoPriceSource) GetPrice(ctx context.Context, chain, address string) (*prices.SourceQuote, error) {
+	token, err := s.client.GetTokenByContract(ctx, chain, address)
+	if err != nil {
+		return nil, err
+	}
+	if token == nil || token.Price == 0 {
+		return nil, fmt.Errorf("coingecko: no price for %s", address)
+	}
+	return &prices.SourceQuote{Source: s.Name(), Price: token.Price, Liquidity: token.Liquidity, Volume24h: token.Volume24h}, nil
+}
+
+// dexscreenerPriceSource adapts DexScreenerClient to prices.PriceSource.
+type dexscreenerPriceSource struct {
+	client *DexScreenerClient
+}
+
+func (s *dexscreenerPriceSource) Name() string { return "dexscreener" }
+
+func (s *dexscreenerPriceSource) GetPrice(ctx context.Context, chain, address string) (*prices.SourceQuote, error) {
+	tokens, err := s.client.SearchToken(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 || tokens[0].Price == 0 {
+		return nil, fmt.Errorf("dexscreener: no price for %s", address)
+	}
+	token := tokens[0]
+	return &prices.SourceQuote{Source: s.Name(), Price: token.Price, Liquidity: token.Liquidity, Volume24h: token.Volume24h}, nil
+}
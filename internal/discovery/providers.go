@@ -0,0 +1,205 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/twadelij/cryptojackal/internal/models"
+	"go.uber.org/zap"
+)
+
+// PriceProvider is the common market-data surface backing discovery's
+// trending/market/contract-lookup lookups. Implementations wrap a single
+// upstream API (CoinGecko, DexScreener, ...).
+type PriceProvider interface {
+	Name() string
+	GetTrendingTokens(ctx context.Context) ([]models.Token, error)
+	GetMarketData(ctx context.Context, limit int) ([]models.Token, error)
+	GetTokenByContract(ctx context.Context, platform, contractAddress string) (*models.Token, error)
+}
+
+// coinGeckoProvider adapts CoinGeckoClient to PriceProvider.
+type coinGeckoProvider struct {
+	client *CoinGeckoClient
+}
+
+func (p *coinGeckoProvider) Name() string { return "coingecko" }
+
+func (p *coinGeckoProvider) GetTrendingTokens(ctx context.Context) ([]models.Token, error) {
+	return p.client.GetTrendingTokens(ctx)
+}
+
+func (p *coinGeckoProvider) GetMarketData(ctx context.Context, limit int) ([]models.Token, error) {
+	return p.client.GetMarketData(ctx, limit)
+}
+
+func (p *coinGeckoProvider) GetTokenByContract(ctx context.Context, platform, contractAddress string) (*models.Token, error) {
+	return p.client.GetTokenByContract(ctx, platform, contractAddress)
+}
+
+// dexScreenerProvider adapts DexScreenerClient to PriceProvider, standing
+// in for the trending/market-data endpoints CoinGecko normally serves
+// using DexScreener's boosted-tokens feed, since DexScreener has no
+// direct equivalent of either.
+type dexScreenerProvider struct {
+	client *DexScreenerClient
+}
+
+func (p *dexScreenerProvider) Name() string { return "dexscreener" }
+
+func (p *dexScreenerProvider) GetTrendingTokens(ctx context.Context) ([]models.Token, error) {
+	return p.client.GetBoostedTokens(ctx)
+}
+
+func (p *dexScreenerProvider) GetMarketData(ctx context.Context, limit int) ([]models.Token, error) {
+	tokens, err := p.client.GetBoostedTokens(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(tokens) > limit {
+		tokens = tokens[:limit]
+	}
+	return tokens, nil
+}
+
+func (p *dexScreenerProvider) GetTokenByContract(ctx context.Context, platform, contractAddress string) (*models.Token, error) {
+	tokens, err := p.client.SearchToken(ctx, contractAddress)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	return &tokens[0], nil
+}
+
+// ProviderHealth is a point-in-time snapshot of one provider's recent
+// success/failure record within a FallbackProvider.
+type ProviderHealth struct {
+	Provider            string    `json:"provider"`
+	Successes           int64     `json:"successes"`
+	Failures            int64     `json:"failures"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	LastError           string    `json:"last_error,omitempty"`
+	LastUsed            time.Time `json:"last_used,omitempty"`
+}
+
+type providerStats struct {
+	mu                  sync.Mutex
+	successes           int64
+	failures            int64
+	consecutiveFailures int
+	lastError           string
+	lastUsed            time.Time
+}
+
+func (s *providerStats) recordSuccess() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.successes++
+	s.consecutiveFailures = 0
+	s.lastUsed = time.Now()
+}
+
+func (s *providerStats) recordFailure(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures++
+	s.consecutiveFailures++
+	s.lastError = err.Error()
+	s.lastUsed = time.Now()
+}
+
+func (s *providerStats) snapshot(name string) ProviderHealth {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return ProviderHealth{
+		Provider:            name,
+		Successes:           s.successes,
+		Failures:            s.failures,
+		ConsecutiveFailures: s.consecutiveFailures,
+		LastError:           s.lastError,
+		LastUsed:            s.lastUsed,
+	}
+}
+
+// FallbackProvider wraps an ordered list of PriceProviders and, on
+// failure from the primary (non-2xx, rate limit, timeout, decode error —
+// anything the upstream client surfaces as an error), transparently
+// retries the next provider in the list. Per-provider health is tracked
+// so a flaky or rate-limited provider can be spotted via Health().
+type FallbackProvider struct {
+	providers []PriceProvider
+	stats     []*providerStats
+	logger    *zap.Logger
+}
+
+// NewFallbackProvider creates a FallbackProvider that tries each provider
+// in order until one succeeds.
+func NewFallbackProvider(providers []PriceProvider, logger *zap.Logger) *FallbackProvider {
+	stats := make([]*providerStats, len(providers))
+	for i := range stats {
+		stats[i] = &providerStats{}
+	}
+	return &FallbackProvider{providers: providers, stats: stats, logger: logger}
+}
+
+func (f *FallbackProvider) GetTrendingTokens(ctx context.Context) ([]models.Token, error) {
+	var lastErr error
+	for i, p := range f.providers {
+		tokens, err := p.GetTrendingTokens(ctx)
+		if err != nil {
+			f.stats[i].recordFailure(err)
+			f.logger.Warn("price provider failed, trying next", zap.String("provider", p.Name()), zap.Error(err))
+			lastErr = err
+			continue
+		}
+		f.stats[i].recordSuccess()
+		return tokens, nil
+	}
+	return nil, fmt.Errorf("all price providers failed: %w", lastErr)
+}
+
+func (f *FallbackProvider) GetMarketData(ctx context.Context, limit int) ([]models.Token, error) {
+	var lastErr error
+	for i, p := range f.providers {
+		tokens, err := p.GetMarketData(ctx, limit)
+		if err != nil {
+			f.stats[i].recordFailure(err)
+			f.logger.Warn("price provider failed, trying next", zap.String("provider", p.Name()), zap.Error(err))
+			lastErr = err
+			continue
+		}
+		f.stats[i].recordSuccess()
+		return tokens, nil
+	}
+	return nil, fmt.Errorf("all price providers failed: %w", lastErr)
+}
+
+func (f *FallbackProvider) GetTokenByContract(ctx context.Context, platform, contractAddress string) (*models.Token, error) {
+	var lastErr error
+	for i, p := range f.providers {
+		token, err := p.GetTokenByContract(ctx, platform, contractAddress)
+		if err != nil {
+			f.stats[i].recordFailure(err)
+			f.logger.Warn("price provider failed, trying next", zap.String("provider", p.Name()), zap.Error(err))
+			lastErr = err
+			continue
+		}
+		f.stats[i].recordSuccess()
+		return token, nil
+	}
+	return nil, fmt.Errorf("all price providers failed: %w", lastErr)
+}
+
+// Health returns a point-in-time snapshot of each wrapped provider's
+// success/failure record, in provider order.
+func (f *FallbackProvider) Health() []ProviderHealth {
+	health := make([]ProviderHealth, len(f.providers))
+	for i, p := range f.providers {
+		health[i] = f.stats[i].snapshot(p.Name())
+	}
+	return health
+}
@@ -0,0 +1,105 @@
+// Package events is a lightweight in-process pub/sub used to fan out
+// state changes (new opportunities, executed trades, portfolio deltas)
+// to the WebSocket API without coupling the trading engine, paper
+// service and discovery service directly to the transport layer.
+package events
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Well-known topic names. Token- and pair-scoped topics are built at
+// runtime with TokenTopic/SignalsTopic.
+const (
+	TopicOpportunities = "opportunities"
+	TopicTrades        = "trades"
+	TopicPortfolio     = "portfolio"
+)
+
+// TokenTopic returns the topic name for updates about a single token.
+func TokenTopic(address string) string { return "token:" + address }
+
+// SignalsTopic returns the topic name for indicator updates on a pair.
+func SignalsTopic(pair string) string { return "signals:" + pair }
+
+// Event is a single published message. Seq is monotonically increasing
+// per Bus so that subscribers (e.g. a reconnecting WebSocket client) can
+// detect gaps in what they've received.
+type Event struct {
+	Seq       uint64      `json:"seq"`
+	Topic     string      `json:"topic"`
+	Type      string      `json:"type"`
+	Payload   interface{} `json:"payload"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
+// subscriberBuffer bounds how many unconsumed events a slow subscriber
+// can accumulate before events are dropped for it.
+const subscriberBuffer = 64
+
+// Bus is an in-process, topic-based publish/subscribe hub.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[chan Event]struct{}
+	seq         uint64
+	logger      *zap.Logger
+}
+
+// NewBus creates a new, empty Bus.
+func NewBus(logger *zap.Logger) *Bus {
+	return &Bus{
+		subscribers: make(map[string]map[chan Event]struct{}),
+		logger:      logger,
+	}
+}
+
+// Publish emits an event of eventType with the given payload on topic to
+// every current subscriber of that topic.
+func (b *Bus) Publish(topic, eventType string, payload interface{}) {
+	event := Event{
+		Seq:       atomic.AddUint64(&b.seq, 1),
+		Topic:     topic,
+		Type:      eventType,
+		Payload:   payload,
+		Timestamp: time.Now(),
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for ch := range b.subscribers[topic] {
+		select {
+		case ch <- event:
+		default:
+			b.logger.Warn("events: subscriber channel full, dropping event", zap.String("topic", topic))
+		}
+	}
+}
+
+// Subscribe registers a new subscriber for topic and returns its event
+// channel plus an unsubscribe function the caller must invoke when done.
+func (b *Bus) Subscribe(topic string) (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	b.mu.Lock()
+	if b.subscribers[topic] == nil {
+		b.subscribers[topic] = make(map[chan Event]struct{})
+	}
+	b.subscribers[topic][ch] = struct{}{}
+	b.mu.Unlock()
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		delete(b.subscribers[topic], ch)
+		if len(b.subscribers[topic]) == 0 {
+			delete(b.subscribers, topic)
+		}
+		b.mu.Unlock()
+		close(ch)
+	}
+
+	return ch, unsubscribe
+}
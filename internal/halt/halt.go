@@ -0,0 +1,194 @@
+// Package halt implements a trading kill-switch: a circuit breaker that
+// both paper and live trading consult before every trade, and that trips
+// automatically on drawdown, losing streaks, or a flaky price layer.
+package halt
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Config sets the thresholds that trip an automatic halt. A zero-valued
+// field disables that particular check.
+type Config struct {
+	MaxDrawdownPct                 float64
+	MaxConsecutiveLosses           int
+	MaxLossPerWindow               float64
+	LossWindow                     time.Duration
+	MaxConsecutiveProviderFailures int
+}
+
+// Trigger identifies why a halt fired.
+type Trigger string
+
+const (
+	TriggerNone              Trigger = ""
+	TriggerManual            Trigger = "manual"
+	TriggerMaxDrawdown       Trigger = "max_drawdown"
+	TriggerConsecutiveLosses Trigger = "consecutive_losses"
+	TriggerLossWindow        Trigger = "loss_window"
+	TriggerProviderFailures  Trigger = "provider_failures"
+)
+
+// lossEvent is one losing trade, kept only to evaluate the rolling
+// loss-window check.
+type lossEvent struct {
+	at     time.Time
+	amount float64
+}
+
+// Manager is the halt circuit breaker. The trading engine and
+// paper.Service.ExecuteTrade must call Check before every trade, and
+// report outcomes via RecordTradeResult/RecordProviderFailure/
+// RecordProviderSuccess so the automatic conditions can trip.
+type Manager struct {
+	cfg    Config
+	logger *zap.Logger
+
+	mu                          sync.Mutex
+	halted                      bool
+	trigger                     Trigger
+	reason                      string
+	haltedAt                    time.Time
+	consecutiveLosses           int
+	consecutiveProviderFailures int
+	losses                      []lossEvent
+}
+
+// NewManager creates a Manager with the given thresholds.
+func NewManager(cfg Config, logger *zap.Logger) *Manager {
+	return &Manager{cfg: cfg, logger: logger}
+}
+
+// Status is a point-in-time snapshot of the halt state.
+type Status struct {
+	Halted   bool      `json:"halted"`
+	Trigger  Trigger   `json:"trigger,omitempty"`
+	Reason   string    `json:"reason,omitempty"`
+	HaltedAt time.Time `json:"halted_at,omitempty"`
+}
+
+// Status returns the current halt state.
+func (m *Manager) Status() Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return Status{Halted: m.halted, Trigger: m.trigger, Reason: m.reason, HaltedAt: m.haltedAt}
+}
+
+// Check reports whether trading is currently halted and, if so, why.
+func (m *Manager) Check() (halted bool, reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.halted, m.reason
+}
+
+// Halt manually halts trading with an operator-supplied reason.
+func (m *Manager) Halt(reason string) {
+	m.trip(TriggerManual, reason)
+}
+
+// Resume clears any active halt, manual or automatic, and resets the
+// counters behind the automatic conditions.
+func (m *Manager) Resume() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.halted = false
+	m.trigger = TriggerNone
+	m.reason = ""
+	m.haltedAt = time.Time{}
+	m.consecutiveLosses = 0
+	m.consecutiveProviderFailures = 0
+	m.losses = nil
+	m.logger.Info("trading resumed")
+}
+
+func (m *Manager) trip(trigger Trigger, reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.halted {
+		return
+	}
+	m.halted = true
+	m.trigger = trigger
+	m.reason = reason
+	m.haltedAt = time.Now()
+	m.logger.Warn("trading halted", zap.String("trigger", string(trigger)), zap.String("reason", reason))
+}
+
+// CheckDrawdown trips the breaker once currentBalance has fallen more
+// than MaxDrawdownPct below initialBalance.
+func (m *Manager) CheckDrawdown(initialBalance, currentBalance float64) {
+	if m.cfg.MaxDrawdownPct <= 0 || initialBalance <= 0 {
+		return
+	}
+	drawdownPct := (initialBalance - currentBalance) / initialBalance * 100
+	if drawdownPct >= m.cfg.MaxDrawdownPct {
+		m.trip(TriggerMaxDrawdown, fmt.Sprintf("drawdown %.2f%% exceeds limit %.2f%%", drawdownPct, m.cfg.MaxDrawdownPct))
+	}
+}
+
+// RecordTradeResult updates the consecutive-loss and rolling-loss-window
+// counters for a completed trade, tripping the breaker if either
+// configured threshold is exceeded. profitLoss >= 0 resets the streak.
+func (m *Manager) RecordTradeResult(profitLoss float64) {
+	if profitLoss >= 0 {
+		m.mu.Lock()
+		m.consecutiveLosses = 0
+		m.mu.Unlock()
+		return
+	}
+
+	m.mu.Lock()
+	m.consecutiveLosses++
+	consecutive := m.consecutiveLosses
+
+	now := time.Now()
+	m.losses = append(m.losses, lossEvent{at: now, amount: -profitLoss})
+	if m.cfg.LossWindow > 0 {
+		cutoff := now.Add(-m.cfg.LossWindow)
+		kept := m.losses[:0]
+		for _, l := range m.losses {
+			if l.at.After(cutoff) {
+				kept = append(kept, l)
+			}
+		}
+		m.losses = kept
+	}
+	var windowLoss float64
+	for _, l := range m.losses {
+		windowLoss += l.amount
+	}
+	m.mu.Unlock()
+
+	if m.cfg.MaxConsecutiveLosses > 0 && consecutive >= m.cfg.MaxConsecutiveLosses {
+		m.trip(TriggerConsecutiveLosses, fmt.Sprintf("%d consecutive losing trades", consecutive))
+		return
+	}
+	if m.cfg.MaxLossPerWindow > 0 && windowLoss >= m.cfg.MaxLossPerWindow {
+		m.trip(TriggerLossWindow, fmt.Sprintf("losses of %.4f within %s exceed limit %.4f", windowLoss, m.cfg.LossWindow, m.cfg.MaxLossPerWindow))
+	}
+}
+
+// RecordProviderFailure tracks a consecutive price/discovery provider
+// failure, tripping the breaker once MaxConsecutiveProviderFailures is
+// reached.
+func (m *Manager) RecordProviderFailure() {
+	m.mu.Lock()
+	m.consecutiveProviderFailures++
+	count := m.consecutiveProviderFailures
+	m.mu.Unlock()
+
+	if m.cfg.MaxConsecutiveProviderFailures > 0 && count >= m.cfg.MaxConsecutiveProviderFailures {
+		m.trip(TriggerProviderFailures, fmt.Sprintf("%d consecutive provider failures", count))
+	}
+}
+
+// RecordProviderSuccess resets the consecutive provider-failure counter.
+func (m *Manager) RecordProviderSuccess() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.consecutiveProviderFailures = 0
+}
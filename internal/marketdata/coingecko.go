@@ -0,0 +1,93 @@
+package marketdata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const coingeckoCandlesBaseURL = "https://api.coingecko.com/api/v3"
+
+// CoinGeckoSource fetches historical candles from CoinGecko's market_chart
+// endpoint, used as a fallback when DexScreener has no chart for a pair.
+type CoinGeckoSource struct {
+	httpClient *http.Client
+	apiKey     string
+	logger     *zap.Logger
+}
+
+// NewCoinGeckoSource creates a new CoinGecko-backed HistoricalSource.
+func NewCoinGeckoSource(apiKey string, logger *zap.Logger) *CoinGeckoSource {
+	return &CoinGeckoSource{
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		apiKey:     apiKey,
+		logger:     logger,
+	}
+}
+
+// GetKlines fetches candles via GET /coins/{chain}/contract/{pairAddress}/market_chart.
+// CoinGecko has no concept of "pairAddress" distinct from a token contract,
+// so pairAddress is treated as the token contract address here.
+func (c *CoinGeckoSource) GetKlines(ctx context.Context, chain, pairAddress string, interval Interval, limit int) ([]Bar, error) {
+	days := daysFor(interval, limit)
+	endpoint := fmt.Sprintf("%s/coins/%s/contract/%s/market_chart/?vs_currency=usd&days=%d",
+		coingeckoCandlesBaseURL, chain, pairAddress, days)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.apiKey != "" {
+		req.Header.Set("x-cg-demo-api-key", c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("coingecko: failed to fetch candles: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("coingecko candles API error: %d", resp.StatusCode)
+	}
+
+	var data struct {
+		Prices [][2]float64 `json:"prices"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("coingecko: failed to decode candles: %w", err)
+	}
+
+	// CoinGecko's market_chart endpoint returns price points rather than
+	// true OHLC; synthesize single-price bars so downstream consumers get
+	// a consistent Bar shape.
+	bars := make([]Bar, 0, len(data.Prices))
+	for _, p := range data.Prices {
+		openTime := time.UnixMilli(int64(p[0]))
+		price := p[1]
+		bars = append(bars, Bar{
+			OpenTime:  openTime,
+			Open:      price,
+			High:      price,
+			Low:       price,
+			Close:     price,
+			CloseTime: openTime.Add(durationFor(interval)),
+		})
+	}
+
+	c.logger.Debug("fetched candles from coingecko", zap.String("contract", pairAddress), zap.Int("count", len(bars)))
+	return bars, nil
+}
+
+func daysFor(interval Interval, limit int) int {
+	barsPerDay := 24 * time.Hour / durationFor(interval)
+	days := limit / int(barsPerDay)
+	if days < 1 {
+		days = 1
+	}
+	return days
+}
@@ -0,0 +1,113 @@
+package marketdata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const dexScreenerCandlesBaseURL = "https://io.dexscreener.com"
+
+// DexScreenerSource fetches historical candles from DexScreener's
+// charting endpoint. DexScreener doesn't expose live trades over a public
+// websocket, so real-time updates are driven by short-poll subscriptions
+// on top of this source rather than a persistent socket.
+type DexScreenerSource struct {
+	httpClient *http.Client
+	logger     *zap.Logger
+}
+
+// NewDexScreenerSource creates a new DexScreener-backed HistoricalSource.
+func NewDexScreenerSource(logger *zap.Logger) *DexScreenerSource {
+	return &DexScreenerSource{
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		logger:     logger,
+	}
+}
+
+type dexScreenerCandle struct {
+	Time   int64   `json:"time"`
+	Open   float64 `json:"open"`
+	High   float64 `json:"high"`
+	Low    float64 `json:"low"`
+	Close  float64 `json:"close"`
+	Volume float64 `json:"volume"`
+}
+
+// GetKlines fetches candles via GET /dex/chart/amm/{chain}/{pairAddress}/bars.
+func (d *DexScreenerSource) GetKlines(ctx context.Context, chain, pairAddress string, interval Interval, limit int) ([]Bar, error) {
+	endpoint := fmt.Sprintf("%s/dex/chart/amm/%s/%s/bars?res=%s&limit=%d",
+		dexScreenerCandlesBaseURL, chain, pairAddress, resolution(interval), limit)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("dexscreener: failed to fetch candles: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("dexscreener candles API error: %d", resp.StatusCode)
+	}
+
+	var candles []dexScreenerCandle
+	if err := json.NewDecoder(resp.Body).Decode(&candles); err != nil {
+		return nil, fmt.Errorf("dexscreener: failed to decode candles: %w", err)
+	}
+
+	bars := make([]Bar, 0, len(candles))
+	for _, c := range candles {
+		openTime := time.Unix(c.Time, 0)
+		bars = append(bars, Bar{
+			OpenTime:  openTime,
+			Open:      c.Open,
+			High:      c.High,
+			Low:       c.Low,
+			Close:     c.Close,
+			Volume:    c.Volume,
+			CloseTime: openTime.Add(durationFor(interval)),
+		})
+	}
+
+	d.logger.Debug("fetched candles from dexscreener", zap.String("pair", pairAddress), zap.Int("count", len(bars)))
+	return bars, nil
+}
+
+// resolution maps an Interval to DexScreener's minute-based resolution param.
+func resolution(interval Interval) string {
+	switch interval {
+	case Interval1m:
+		return "1"
+	case Interval5m:
+		return "5"
+	case Interval15m:
+		return "15"
+	case Interval1h:
+		return "60"
+	default:
+		return "5"
+	}
+}
+
+func durationFor(interval Interval) time.Duration {
+	switch interval {
+	case Interval1m:
+		return time.Minute
+	case Interval5m:
+		return 5 * time.Minute
+	case Interval15m:
+		return 15 * time.Minute
+	case Interval1h:
+		return time.Hour
+	default:
+		return 5 * time.Minute
+	}
+}
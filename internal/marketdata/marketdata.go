@@ -0,0 +1,147 @@
+// Package marketdata ingests OHLCV candlesticks per token/pair and keeps
+// a rolling in-memory window per (chain, pairAddress, interval) for use
+// by the signals package and the discovery scanner.
+package marketdata
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Interval is a candle interval, e.g. "1m", "5m", "1h".
+type Interval string
+
+const (
+	Interval1m  Interval = "1m"
+	Interval5m  Interval = "5m"
+	Interval15m Interval = "15m"
+	Interval1h  Interval = "1h"
+)
+
+// Bar is a single OHLCV candlestick.
+type Bar struct {
+	OpenTime  time.Time `json:"open_time"`
+	Open      float64   `json:"open"`
+	High      float64   `json:"high"`
+	Low       float64   `json:"low"`
+	Close     float64   `json:"close"`
+	Volume    float64   `json:"volume"`
+	CloseTime time.Time `json:"close_time"`
+}
+
+// windowKey identifies a rolling bar window.
+type windowKey struct {
+	chain       string
+	pairAddress string
+	interval    Interval
+}
+
+// maxBarsPerWindow bounds memory use; older bars are dropped as new ones
+// close.
+const maxBarsPerWindow = 500
+
+// HistoricalSource fetches historical bars from an upstream provider.
+type HistoricalSource interface {
+	GetKlines(ctx context.Context, chain, pairAddress string, interval Interval, limit int) ([]Bar, error)
+}
+
+// Service stores rolling OHLCV windows and notifies subscribers when a
+// bar closes.
+type Service struct {
+	sources []HistoricalSource
+	logger  *zap.Logger
+
+	mu      sync.RWMutex
+	windows map[windowKey][]Bar
+
+	subMu       sync.RWMutex
+	subscribers map[windowKey][]chan Bar
+}
+
+// NewService creates a Service backed by one or more historical sources,
+// tried in order until one succeeds (mirroring the discovery package's
+// DexScreener-then-CoinGecko fallback pattern).
+func NewService(logger *zap.Logger, sources ...HistoricalSource) *Service {
+	return &Service{
+		sources:     sources,
+		logger:      logger,
+		windows:     make(map[windowKey][]Bar),
+		subscribers: make(map[windowKey][]chan Bar),
+	}
+}
+
+// Load fetches historical bars for (chain, pairAddress, interval) and
+// seeds the rolling window, trying each configured source in order.
+func (s *Service) Load(ctx context.Context, chain, pairAddress string, interval Interval, limit int) ([]Bar, error) {
+	var lastErr error
+	for _, src := range s.sources {
+		bars, err := src.GetKlines(ctx, chain, pairAddress, interval, limit)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		key := windowKey{chain, pairAddress, interval}
+		s.mu.Lock()
+		s.windows[key] = trim(bars)
+		s.mu.Unlock()
+		return bars, nil
+	}
+	if lastErr != nil {
+		return nil, fmt.Errorf("all market data sources failed: %w", lastErr)
+	}
+	return nil, fmt.Errorf("no market data sources configured")
+}
+
+// Klines returns the currently held rolling window for (chain,
+// pairAddress, interval), without triggering a fetch.
+func (s *Service) Klines(chain, pairAddress string, interval Interval) []Bar {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.windows[windowKey{chain, pairAddress, interval}]
+}
+
+// OnBarClose appends a newly closed bar to the rolling window and fans it
+// out to any registered subscribers. Nothing in this repository calls it.
+func (s *Service) OnBarClose(chain, pairAddress string, interval Interval, bar Bar) {
+	key := windowKey{chain, pairAddress, interval}
+
+	s.mu.Lock()
+	s.windows[key] = trim(append(s.windows[key], bar))
+	s.mu.Unlock()
+
+	s.subMu.RLock()
+	defer s.subMu.RUnlock()
+	for _, ch := range s.subscribers[key] {
+		select {
+		case ch <- bar:
+		default:
+			s.logger.Warn("marketdata subscriber channel full, dropping bar", zap.String("pair", pairAddress))
+		}
+	}
+}
+
+// Subscribe returns a channel that receives newly closed bars for
+// (chain, pairAddress, interval). Callers own the channel for the
+// lifetime of the subscription; there is no Unsubscribe, so a channel
+// stays registered for the life of the Service.
+func (s *Service) Subscribe(chain, pairAddress string, interval Interval) <-chan Bar {
+	key := windowKey{chain, pairAddress, interval}
+	ch := make(chan Bar, 16)
+
+	s.subMu.Lock()
+	s.subscribers[key] = append(s.subscribers[key], ch)
+	s.subMu.Unlock()
+
+	return ch
+}
+
+func trim(bars []Bar) []Bar {
+	if len(bars) <= maxBarsPerWindow {
+		return bars
+	}
+	return bars[len(bars)-maxBarsPerWindow:]
+}
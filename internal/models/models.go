@@ -22,6 +22,32 @@ type Token struct {
 	Tags             []string  `json:"tags"`
 }
 
+// KlinePeriod is how far back a kline query looks, mirroring CoinGecko's
+// supported /ohlc day windows.
+type KlinePeriod string
+
+const (
+	KlinePeriod1Day    KlinePeriod = "1"
+	KlinePeriod7Days   KlinePeriod = "7"
+	KlinePeriod14Days  KlinePeriod = "14"
+	KlinePeriod30Days  KlinePeriod = "30"
+	KlinePeriod90Days  KlinePeriod = "90"
+	KlinePeriod180Days KlinePeriod = "180"
+	KlinePeriod365Days KlinePeriod = "365"
+)
+
+// Kline is a single historical OHLCV candle.
+type Kline struct {
+	OpenTime  time.Time   `json:"open_time"`
+	Open      float64     `json:"open"`
+	High      float64     `json:"high"`
+	Low       float64     `json:"low"`
+	Close     float64     `json:"close"`
+	Volume    float64     `json:"volume"`
+	CloseTime time.Time   `json:"close_time"`
+	Interval  KlinePeriod `json:"interval"`
+}
+
 // TradingOpportunity represents a potential trade
 type TradingOpportunity struct {
 	ID              string    `json:"id"`
@@ -30,6 +56,8 @@ type TradingOpportunity struct {
 	PriceImpact     float64   `json:"price_impact"`
 	ConfidenceScore float64   `json:"confidence_score"`
 	Strategy        string    `json:"strategy"`
+	StopLoss        float64   `json:"stop_loss,omitempty"`
+	TakeProfit      float64   `json:"take_profit,omitempty"`
 	CreatedAt       time.Time `json:"created_at"`
 	ExpiresAt       time.Time `json:"expires_at"`
 }
@@ -66,16 +94,22 @@ const (
 	TradeStatusExecuted  TradeStatus = "executed"
 	TradeStatusFailed    TradeStatus = "failed"
 	TradeStatusCancelled TradeStatus = "cancelled"
+	TradeStatusRejected  TradeStatus = "rejected"
 )
 
 // Portfolio represents a trading portfolio
 type Portfolio struct {
-	ID           string            `json:"id"`
-	ETHBalance   float64           `json:"eth_balance"`
-	TokenBalances map[string]TokenBalance `json:"token_balances"`
-	TotalValue   float64           `json:"total_value"`
-	ProfitLoss   float64           `json:"profit_loss"`
-	UpdatedAt    time.Time         `json:"updated_at"`
+	ID              string                    `json:"id"`
+	Balance         float64                   `json:"balance"`
+	Currency        string                    `json:"currency"`
+	ETHBalance      float64                   `json:"eth_balance"`
+	TokenBalances   map[string]TokenBalance   `json:"token_balances"`
+	TotalValue      float64                   `json:"total_value"`
+	ProfitLoss      float64                   `json:"profit_loss"`
+	ProfitLossPct   float64                   `json:"profit_loss_pct"`
+	Loans           map[string]Loan           `json:"loans,omitempty"`
+	MarginPositions map[string]MarginPosition `json:"margin_positions,omitempty"`
+	UpdatedAt       time.Time                 `json:"updated_at"`
 }
 
 type TokenBalance struct {
@@ -85,6 +119,31 @@ type TokenBalance struct {
 	AvgPrice float64 `json:"avg_price"`
 }
 
+// Loan is an outstanding margin loan taken out in Asset to fund a
+// leveraged position. InterestRate is hourly (e.g. 0.0001 = 0.01%/hour);
+// AccruedInterest is recomputed as simple interest over elapsed time.
+type Loan struct {
+	ID              string    `json:"id"`
+	Asset           string    `json:"asset"`
+	Principal       float64   `json:"principal"`
+	InterestRate    float64   `json:"interest_rate"`
+	OpenedAt        time.Time `json:"opened_at"`
+	AccruedInterest float64   `json:"accrued_interest"`
+}
+
+// MarginPosition is an open leveraged position funded by a Loan.
+// LiquidationPrice is the mark price at which equity (position value less
+// the outstanding loan) falls to the maintenance-margin fraction of
+// position value and the position is force-closed.
+type MarginPosition struct {
+	Token            Token   `json:"token"`
+	Size             float64 `json:"size"`
+	EntryPrice       float64 `json:"entry_price"`
+	Leverage         float64 `json:"leverage"`
+	LiquidationPrice float64 `json:"liquidation_price"`
+	LoanID           string  `json:"loan_id"`
+}
+
 // BotStatus represents the current bot status
 type BotStatus struct {
 	IsRunning       bool      `json:"is_running"`
@@ -95,6 +154,9 @@ type BotStatus struct {
 	TotalProfitLoss float64   `json:"total_profit_loss"`
 	CurrentBalance  float64   `json:"current_balance"`
 	ActiveOpportunities int   `json:"active_opportunities"`
+	Halted          bool      `json:"halted"`
+	HaltTrigger     string    `json:"halt_trigger,omitempty"`
+	HaltReason      string    `json:"halt_reason,omitempty"`
 }
 
 // Metrics for monitoring
@@ -109,6 +171,9 @@ type Metrics struct {
 	AverageProfitPerTrade float64   `json:"average_profit_per_trade"`
 	TokensDiscovered  int           `json:"tokens_discovered"`
 	OpportunitiesFound int          `json:"opportunities_found"`
+	TotalBorrowed     float64       `json:"total_borrowed"`
+	TotalInterestPaid float64       `json:"total_interest_paid"`
+	LiquidationCount  int           `json:"liquidation_count"`
 }
 
 // NewTrade creates a new trade with a generated ID
@@ -0,0 +1,52 @@
+package paper
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock abstracts the passage of time so tests and the debug API can
+// drive Service deterministically instead of waiting on the wall clock.
+type Clock interface {
+	Now() time.Time
+}
+
+// RealClock is the production Clock, backed by time.Now.
+type RealClock struct{}
+
+// Now returns the current wall-clock time.
+func (RealClock) Now() time.Time { return time.Now() }
+
+// MockClock is a manually-advanced Clock for deterministic tests and the
+// /api/debug/* scenario harness. The zero value is not usable; construct
+// one with NewMockClock.
+type MockClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewMockClock returns a MockClock starting at start.
+func NewMockClock(start time.Time) *MockClock {
+	return &MockClock{now: start}
+}
+
+// Now returns the clock's current simulated time.
+func (c *MockClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the simulated time forward by d.
+func (c *MockClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set pins the simulated time to t.
+func (c *MockClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
@@ -7,35 +7,149 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/twadelij/cryptojackal/internal/events"
+	"github.com/twadelij/cryptojackal/internal/halt"
 	"github.com/twadelij/cryptojackal/internal/models"
+	"github.com/twadelij/cryptojackal/internal/storage"
 	"go.uber.org/zap"
 )
 
+// marginCheckInterval is how often the background loop recomputes
+// accrued interest and checks open margin positions for liquidation.
+const marginCheckInterval = time.Minute
+
+// MarginConfig configures paper margin trading: the hourly interest rate
+// charged on loans, and the maintenance margin ratio (equity / position
+// value) below which a leveraged position is liquidated. A zero-valued
+// HourlyInterestRate disables margin trading entirely; ExecuteTrade then
+// rejects any buy with leverage > 1, and Borrow always errors.
+type MarginConfig struct {
+	HourlyInterestRate float64
+	MaintenanceMargin  float64
+}
+
+// PriceLookup returns the current price for a token address, used by the
+// background margin loop to mark open leveraged positions to market.
+type PriceLookup func(ctx context.Context, address string) (float64, error)
+
 // Service manages paper trading simulation
 type Service struct {
 	mu             sync.RWMutex
 	portfolio      *models.Portfolio
 	trades         []models.Trade
 	initialBalance float64
+	events         *events.Bus
+	halt           *halt.Manager
 	logger         *zap.Logger
+
+	marginCfg         MarginConfig
+	priceLookup       PriceLookup
+	totalBorrowed     float64
+	totalInterestPaid float64
+	liquidationCount  int
+
+	clock Clock
+	store storage.Store
 }
 
-// NewService creates a new paper trading service
-func NewService(initialBalance float64, logger *zap.Logger) *Service {
-	return &Service{
+// NewService creates a new paper trading service. bus is optional; when
+// nil, trade and portfolio updates simply aren't published anywhere.
+// haltMgr is optional; when nil, ExecuteTrade never rejects a trade on
+// the breaker's account. marginCfg enables leveraged buys and margin
+// loans; a zero value disables margin trading. priceLookup is optional
+// and only needed when margin trading is enabled: it lets the background
+// loop mark open positions to market and liquidate them, and is never
+// consulted otherwise. clock is optional; when nil, the service uses
+// RealClock. Passing a *MockClock lets tests and the /api/debug/* harness
+// drive trade timestamps and interest accrual deterministically. store is
+// optional; when nil, the portfolio and trade history live in memory only
+// and are lost on restart. When set, the last-saved portfolio is loaded
+// here, and every trade and portfolio mutation is persisted.
+func NewService(initialBalance float64, bus *events.Bus, haltMgr *halt.Manager, marginCfg MarginConfig, priceLookup PriceLookup, clock Clock, store storage.Store, logger *zap.Logger) *Service {
+	if clock == nil {
+		clock = RealClock{}
+	}
+
+	s := &Service{
 		portfolio: &models.Portfolio{
-			ID:            uuid.New().String(),
-			Balance:       initialBalance,
-			Currency:      "EUR",
-			ETHBalance:    initialBalance, // Keep for backward compat
-			TokenBalances: make(map[string]models.TokenBalance),
-			TotalValue:    initialBalance,
-			UpdatedAt:     time.Now(),
+			ID:              uuid.New().String(),
+			Balance:         initialBalance,
+			Currency:        "EUR",
+			ETHBalance:      initialBalance, // Keep for backward compat
+			TokenBalances:   make(map[string]models.TokenBalance),
+			Loans:           make(map[string]models.Loan),
+			MarginPositions: make(map[string]models.MarginPosition),
+			TotalValue:      initialBalance,
+			UpdatedAt:       clock.Now(),
 		},
 		trades:         make([]models.Trade, 0),
 		initialBalance: initialBalance,
+		events:         bus,
+		halt:           haltMgr,
+		marginCfg:      marginCfg,
+		priceLookup:    priceLookup,
+		clock:          clock,
+		store:          store,
 		logger:         logger,
 	}
+
+	if store != nil {
+		s.loadFromStore()
+	}
+
+	if marginCfg.HourlyInterestRate > 0 {
+		go s.runMarginLoop()
+	}
+
+	return s
+}
+
+// loadFromStore restores the portfolio and trade history saved by a
+// previous run. Failures are logged and otherwise ignored: starting fresh
+// is preferable to refusing to start.
+func (s *Service) loadFromStore() {
+	ctx := context.Background()
+
+	if portfolio, err := s.store.LoadPortfolio(ctx); err != nil {
+		s.logger.Warn("paper: failed to load saved portfolio, starting fresh", zap.Error(err))
+	} else if portfolio != nil {
+		s.portfolio = portfolio
+	}
+
+	trades, err := s.store.LoadTrades(ctx, storage.TradeFilter{})
+	if err != nil {
+		s.logger.Warn("paper: failed to load saved trade history", zap.Error(err))
+		return
+	}
+	// LoadTrades returns most-recent-first; s.trades is chronological.
+	for i := len(trades) - 1; i >= 0; i-- {
+		s.trades = append(s.trades, trades[i])
+	}
+}
+
+// persist saves the current trade and portfolio snapshot, if a store is
+// configured. Callers must hold s.mu. Persistence failures are logged but
+// never fail the trade itself.
+func (s *Service) persist(trade *models.Trade) {
+	if s.store == nil {
+		return
+	}
+	ctx := context.Background()
+	if trade != nil {
+		if err := s.store.SaveTrade(ctx, *trade); err != nil {
+			s.logger.Warn("paper: failed to persist trade", zap.String("trade_id", trade.ID), zap.Error(err))
+		}
+	}
+	if err := s.store.SavePortfolio(ctx, s.portfolio); err != nil {
+		s.logger.Warn("paper: failed to persist portfolio", zap.Error(err))
+	}
+}
+
+// publish emits an event on the configured bus, if any.
+func (s *Service) publish(topic, eventType string, payload interface{}) {
+	if s.events != nil {
+		s.events.Publish(topic, eventType, payload)
+	}
 }
 
 // GetPortfolio returns the current portfolio
@@ -58,8 +172,23 @@ func (s *Service) GetPortfolio() *models.Portfolio {
 	return s.portfolio
 }
 
-// ExecuteTrade executes a paper trade
-func (s *Service) ExecuteTrade(ctx context.Context, token models.Token, tradeType models.TradeType, amount float64) (*models.Trade, error) {
+// ExecuteTrade executes a paper trade. leverage <= 1 is a plain cash
+// trade; leverage > 1 on a buy borrows the difference between the full
+// position cost and the margin posted from balance, opening a Loan and
+// an open MarginPosition, and requires margin trading to be configured.
+func (s *Service) ExecuteTrade(ctx context.Context, token models.Token, tradeType models.TradeType, amount float64, leverage float64) (*models.Trade, error) {
+	if leverage < 1 {
+		leverage = 1
+	}
+
+	if s.halt != nil {
+		if haltedNow, reason := s.halt.Check(); haltedNow {
+			trade := models.NewTrade(token.Address, token.Symbol, tradeType, amount, token.Price, true)
+			trade.Status = models.TradeStatusRejected
+			return trade, fmt.Errorf("trading halted: %s", reason)
+		}
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -67,13 +196,48 @@ func (s *Service) ExecuteTrade(ctx context.Context, token models.Token, tradeTyp
 
 	switch tradeType {
 	case models.TradeTypeBuy:
+		if leverage > 1 && s.marginCfg.HourlyInterestRate <= 0 {
+			trade.Status = models.TradeStatusFailed
+			return trade, fmt.Errorf("margin trading not enabled")
+		}
+
 		cost := amount * token.Price
-		if cost > s.portfolio.Balance {
+		margin := cost / leverage
+		borrowed := cost - margin
+		if margin > s.portfolio.Balance {
 			trade.Status = models.TradeStatusFailed
-			return trade, fmt.Errorf("insufficient balance: need %.2f EUR, have %.2f", cost, s.portfolio.Balance)
+			return trade, fmt.Errorf("insufficient balance: need %.2f EUR margin, have %.2f", margin, s.portfolio.Balance)
 		}
 
-		s.portfolio.Balance -= cost
+		s.portfolio.Balance -= margin
+
+		existingPos, hasPos := s.portfolio.MarginPositions[token.Address]
+
+		var loanID string
+		if borrowed > 0 {
+			if hasPos && existingPos.LoanID != "" {
+				// Fold into the loan already backing this position instead
+				// of opening a second one the position never references
+				// again.
+				loanID = existingPos.LoanID
+				loan := s.portfolio.Loans[loanID]
+				loan.Principal += borrowed
+				s.portfolio.Loans[loanID] = loan
+			} else {
+				loan := models.Loan{
+					ID:           uuid.New().String(),
+					Asset:        s.portfolio.Currency,
+					Principal:    borrowed,
+					InterestRate: s.marginCfg.HourlyInterestRate,
+					OpenedAt:     s.clock.Now(),
+				}
+				s.portfolio.Loans[loan.ID] = loan
+				loanID = loan.ID
+			}
+			s.totalBorrowed += borrowed
+		} else if hasPos {
+			loanID = existingPos.LoanID
+		}
 
 		// Update token balance
 		existing, ok := s.portfolio.TokenBalances[token.Address]
@@ -95,6 +259,27 @@ func (s *Service) ExecuteTrade(ctx context.Context, token models.Token, tradeTyp
 			}
 		}
 
+		if leverage > 1 {
+			size := amount
+			entryPrice := token.Price
+			if hasPos {
+				size = existingPos.Size + amount
+				entryPrice = (existingPos.EntryPrice*existingPos.Size + token.Price*amount) / size
+			}
+			var principal float64
+			if loanID != "" {
+				principal = s.portfolio.Loans[loanID].Principal
+			}
+			s.portfolio.MarginPositions[token.Address] = models.MarginPosition{
+				Token:            token,
+				Size:             size,
+				EntryPrice:       entryPrice,
+				Leverage:         leverage,
+				LiquidationPrice: liquidationPrice(size, principal, s.marginCfg.MaintenanceMargin),
+				LoanID:           loanID,
+			}
+		}
+
 		trade.AmountOut = amount
 		trade.Status = models.TradeStatusExecuted
 
@@ -120,26 +305,395 @@ func (s *Service) ExecuteTrade(ctx context.Context, token models.Token, tradeTyp
 			}
 		}
 
+		// Closing a fully-sold margin position repays its loan in full; a
+		// partial sell proportionally reduces the position and its loan
+		// instead, so LiquidationPrice keeps tracking the size still open.
+		if pos, ok := s.portfolio.MarginPositions[token.Address]; ok {
+			if newBalance < 0.0001 {
+				s.settleLoan(pos.LoanID)
+				delete(s.portfolio.MarginPositions, token.Address)
+			} else {
+				s.reduceMarginPosition(token.Address, pos, amount)
+			}
+		}
+
 		// Calculate profit/loss
 		trade.ProfitLoss = (token.Price - existing.AvgPrice) * amount
 		trade.AmountOut = proceeds
 		trade.Status = models.TradeStatusExecuted
 	}
 
-	trade.ExecutedAt = time.Now()
+	trade.ExecutedAt = s.clock.Now()
 	s.trades = append(s.trades, *trade)
-	s.portfolio.UpdatedAt = time.Now()
+	s.portfolio.UpdatedAt = s.clock.Now()
 
 	s.logger.Info("paper trade executed",
 		zap.String("type", string(tradeType)),
 		zap.String("token", token.Symbol),
 		zap.Float64("amount", amount),
 		zap.Float64("price", token.Price),
+		zap.Float64("leverage", leverage),
 	)
 
+	s.publish(events.TopicTrades, "trade_executed", trade)
+	s.publish(events.TopicPortfolio, "balance_updated", s.portfolio)
+
+	if s.halt != nil {
+		if tradeType == models.TradeTypeSell {
+			s.halt.RecordTradeResult(trade.ProfitLoss)
+		}
+		total := s.portfolio.Balance
+		for _, balance := range s.portfolio.TokenBalances {
+			total += balance.Value
+		}
+		s.halt.CheckDrawdown(s.initialBalance, total)
+	}
+
+	s.persist(trade)
 	return trade, nil
 }
 
+// liquidationPrice returns the mark price at which a long position of
+// size funded by borrowed (principal only, ignoring accrued interest)
+// leaves equity at exactly maintenanceMargin of position value.
+func liquidationPrice(size, borrowed, maintenanceMargin float64) float64 {
+	if size <= 0 || maintenanceMargin >= 1 {
+		return 0
+	}
+	return borrowed / (size * (1 - maintenanceMargin))
+}
+
+// settleLoan pays off a loan in full from the portfolio balance,
+// recording the interest paid. Callers must hold s.mu. Balance is
+// allowed to go negative; this mirrors a real margin call where the
+// shortfall becomes portfolio loss rather than a blocked operation.
+func (s *Service) settleLoan(loanID string) {
+	loan, ok := s.portfolio.Loans[loanID]
+	if !ok {
+		return
+	}
+	hours := s.clock.Now().Sub(loan.OpenedAt).Hours()
+	loan.AccruedInterest = loan.Principal * loan.InterestRate * hours
+
+	s.portfolio.Balance -= loan.Principal + loan.AccruedInterest
+	s.totalInterestPaid += loan.AccruedInterest
+	delete(s.portfolio.Loans, loanID)
+}
+
+// reduceMarginPosition proportionally shrinks pos by amountSold, settling
+// that same fraction of its backing loan (principal plus interest accrued
+// so far) from the portfolio balance — the same accounting settleLoan
+// does for a full close, just scaled down — and recomputes LiquidationPrice
+// from what's left open. Callers must hold s.mu.
+func (s *Service) reduceMarginPosition(address string, pos models.MarginPosition, amountSold float64) {
+	fraction := amountSold / pos.Size
+	if fraction > 1 {
+		fraction = 1
+	}
+
+	var remainingPrincipal float64
+	if loan, ok := s.portfolio.Loans[pos.LoanID]; ok {
+		hours := s.clock.Now().Sub(loan.OpenedAt).Hours()
+		accrued := loan.Principal * loan.InterestRate * hours
+		repayPrincipal := loan.Principal * fraction
+		repayInterest := accrued * fraction
+
+		s.portfolio.Balance -= repayPrincipal + repayInterest
+		s.totalInterestPaid += repayInterest
+		loan.Principal -= repayPrincipal
+
+		if loan.Principal <= 0.0001 {
+			delete(s.portfolio.Loans, pos.LoanID)
+		} else {
+			s.portfolio.Loans[pos.LoanID] = loan
+			remainingPrincipal = loan.Principal
+		}
+	}
+
+	pos.Size -= amountSold
+	pos.LiquidationPrice = liquidationPrice(pos.Size, remainingPrincipal, s.marginCfg.MaintenanceMargin)
+	s.portfolio.MarginPositions[address] = pos
+}
+
+// Borrow manually opens a margin loan against the portfolio, independent
+// of a leveraged buy, crediting the borrowed amount to the balance.
+func (s *Service) Borrow(amount float64) (*models.Loan, error) {
+	if s.marginCfg.HourlyInterestRate <= 0 {
+		return nil, fmt.Errorf("margin trading not enabled")
+	}
+	if amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	loan := models.Loan{
+		ID:           uuid.New().String(),
+		Asset:        s.portfolio.Currency,
+		Principal:    amount,
+		InterestRate: s.marginCfg.HourlyInterestRate,
+		OpenedAt:     s.clock.Now(),
+	}
+	s.portfolio.Loans[loan.ID] = loan
+	s.portfolio.Balance += amount
+	s.totalBorrowed += amount
+
+	s.logger.Info("margin loan opened", zap.String("loan_id", loan.ID), zap.Float64("amount", amount))
+	s.persist(nil)
+	return &loan, nil
+}
+
+// Repay pays down an outstanding loan by id from the portfolio balance,
+// settling accrued interest before principal. The loan is removed once
+// both are paid off.
+func (s *Service) Repay(loanID string, amount float64) (*models.Loan, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	loan, ok := s.portfolio.Loans[loanID]
+	if !ok {
+		return nil, fmt.Errorf("loan %q not found", loanID)
+	}
+	if amount > s.portfolio.Balance {
+		return nil, fmt.Errorf("insufficient balance: need %.2f, have %.2f", amount, s.portfolio.Balance)
+	}
+
+	hours := s.clock.Now().Sub(loan.OpenedAt).Hours()
+	loan.AccruedInterest = loan.Principal * loan.InterestRate * hours
+
+	s.portfolio.Balance -= amount
+	remaining := amount
+	interestPaid := remaining
+	if interestPaid > loan.AccruedInterest {
+		interestPaid = loan.AccruedInterest
+	}
+	loan.AccruedInterest -= interestPaid
+	s.totalInterestPaid += interestPaid
+	remaining -= interestPaid
+
+	loan.Principal -= remaining
+	if loan.Principal < 0 {
+		loan.Principal = 0
+	}
+
+	if loan.Principal <= 0.0001 && loan.AccruedInterest <= 0.0001 {
+		delete(s.portfolio.Loans, loanID)
+		s.logger.Info("margin loan repaid in full", zap.String("loan_id", loanID))
+		s.persist(nil)
+		return &loan, nil
+	}
+
+	s.portfolio.Loans[loanID] = loan
+	s.logger.Info("margin loan partially repaid", zap.String("loan_id", loanID), zap.Float64("amount", amount))
+	s.persist(nil)
+	return &loan, nil
+}
+
+// Seed preloads a token balance directly, bypassing the normal buy path
+// (no cost is deducted from the cash balance). It exists for the
+// /api/debug/seed harness, which needs to put a portfolio into a known
+// state before a test scenario runs.
+func (s *Service) Seed(token models.Token, amount, avgPrice float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.portfolio.TokenBalances[token.Address] = models.TokenBalance{
+		Token:    token,
+		Balance:  amount,
+		Value:    amount * token.Price,
+		AvgPrice: avgPrice,
+	}
+	s.portfolio.UpdatedAt = s.clock.Now()
+	s.persist(nil)
+}
+
+// Tick marks an existing token balance to a new price and volume,
+// liquidating its margin position if the price has breached the
+// liquidation threshold. A token with no existing balance gets no new
+// balance entry, but the portfolio's UpdatedAt is still bumped and
+// persisted and the price_tick event is still published. It exists for the /api/debug/tick and /api/debug/scenario
+// harness, which drive price movement without a live quote source.
+func (s *Service) Tick(token models.Token, volume float64) {
+	token.Volume24h = volume
+
+	s.mu.Lock()
+	existing, ok := s.portfolio.TokenBalances[token.Address]
+	if ok {
+		existing.Token = token
+		existing.Value = existing.Balance * token.Price
+		s.portfolio.TokenBalances[token.Address] = existing
+	}
+	pos, hasPos := s.portfolio.MarginPositions[token.Address]
+	s.portfolio.UpdatedAt = s.clock.Now()
+	s.persist(nil)
+	s.mu.Unlock()
+
+	if hasPos && token.Price <= pos.LiquidationPrice {
+		s.liquidatePosition(token.Address, token.Price)
+	}
+
+	s.publish(events.TopicPortfolio, "price_tick", token)
+}
+
+// AdvanceClock moves the service's clock forward by d. It errors unless
+// the service was constructed with a *MockClock, which is the case only
+// when the /api/debug/* harness is enabled.
+func (s *Service) AdvanceClock(d time.Duration) error {
+	mock, ok := s.clock.(*MockClock)
+	if !ok {
+		return fmt.Errorf("clock is not a mock clock")
+	}
+	mock.Advance(d)
+	return nil
+}
+
+// GetLoans returns all outstanding margin loans.
+func (s *Service) GetLoans() []models.Loan {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	loans := make([]models.Loan, 0, len(s.portfolio.Loans))
+	for _, loan := range s.portfolio.Loans {
+		loans = append(loans, loan)
+	}
+	return loans
+}
+
+// InterestSummary is the lifetime borrowing record surfaced by
+// GET /api/paper/margin/interests.
+type InterestSummary struct {
+	TotalBorrowed     float64 `json:"total_borrowed"`
+	TotalInterestPaid float64 `json:"total_interest_paid"`
+	LiquidationCount  int     `json:"liquidation_count"`
+}
+
+// GetInterestSummary returns the running total borrowed, interest paid,
+// and liquidation count across the life of the portfolio.
+func (s *Service) GetInterestSummary() InterestSummary {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return InterestSummary{
+		TotalBorrowed:     s.totalBorrowed,
+		TotalInterestPaid: s.totalInterestPaid,
+		LiquidationCount:  s.liquidationCount,
+	}
+}
+
+// runMarginLoop periodically accrues interest on outstanding loans and
+// liquidates any position whose mark price has breached its liquidation
+// price. It runs for the lifetime of the process once started.
+func (s *Service) runMarginLoop() {
+	ticker := time.NewTicker(marginCheckInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		s.accrueAndCheckLiquidations(context.Background())
+	}
+}
+
+// accrueAndCheckLiquidations recomputes simple interest on every
+// outstanding loan, then — if a PriceLookup is configured — marks every
+// open margin position to market and liquidates any that have breached
+// their liquidation price.
+func (s *Service) accrueAndCheckLiquidations(ctx context.Context) {
+	s.mu.Lock()
+	for id, loan := range s.portfolio.Loans {
+		hours := s.clock.Now().Sub(loan.OpenedAt).Hours()
+		loan.AccruedInterest = loan.Principal * loan.InterestRate * hours
+		s.portfolio.Loans[id] = loan
+	}
+	positions := make(map[string]models.MarginPosition, len(s.portfolio.MarginPositions))
+	for addr, pos := range s.portfolio.MarginPositions {
+		positions[addr] = pos
+	}
+	s.mu.Unlock()
+
+	if s.priceLookup == nil {
+		return
+	}
+
+	for addr, pos := range positions {
+		price, err := s.priceLookup(ctx, addr)
+		if err != nil {
+			continue
+		}
+		if price <= pos.LiquidationPrice {
+			s.liquidatePosition(addr, price)
+		}
+	}
+}
+
+// liquidatePosition force-closes a margin position at price, settling
+// its loan and recording the liquidation.
+func (s *Service) liquidatePosition(address string, price float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pos, ok := s.portfolio.MarginPositions[address]
+	if !ok {
+		return
+	}
+
+	s.portfolio.Balance += pos.Size * price
+	s.settleLoan(pos.LoanID)
+	delete(s.portfolio.TokenBalances, address)
+	delete(s.portfolio.MarginPositions, address)
+	s.liquidationCount++
+
+	s.logger.Warn("margin position liquidated",
+		zap.String("token", pos.Token.Symbol),
+		zap.Float64("price", price),
+		zap.Float64("liquidation_price", pos.LiquidationPrice),
+	)
+	s.publish(events.TopicPortfolio, "position_liquidated", pos)
+	s.persist(nil)
+}
+
+// KlineStrategy decides what trade, if any, to make for token at the
+// current point in a kline replay. execute=false is a no-op for this
+// candle.
+type KlineStrategy func(candle models.Kline, token models.Token) (tradeType models.TradeType, amount float64, execute bool)
+
+// ReplayKlines iterates klines chronologically (callers are responsible
+// for passing them already in ascending-time order), updating token's
+// price to each candle's close before asking strategy whether to trade.
+// Trades execute through the normal ExecuteTrade path at 1x leverage, so
+// they're recorded in history exactly like a live paper trade, and are
+// also returned in order for convenience. This lets a backtest replay a
+// historical window through the same trade logic as live ticks use. When
+// s was constructed with a *MockClock, the clock is pinned to each
+// candle's CloseTime before executing its trade, so ExecutedAt reflects
+// the candle's true historical time instead of wall-clock "now".
+func (s *Service) ReplayKlines(ctx context.Context, token models.Token, klines []models.Kline, strategy KlineStrategy) ([]models.Trade, error) {
+	mockClock, isMock := s.clock.(*MockClock)
+	if !isMock {
+		s.logger.Warn("kline replay: clock is not a mock clock, trade timestamps will use real time instead of each candle's close time")
+	}
+
+	trades := make([]models.Trade, 0, len(klines))
+	for _, candle := range klines {
+		token.Price = candle.Close
+		if isMock {
+			mockClock.Set(candle.CloseTime)
+		}
+
+		tradeType, amount, execute := strategy(candle, token)
+		if !execute {
+			continue
+		}
+
+		trade, err := s.ExecuteTrade(ctx, token, tradeType, amount, 1)
+		if err != nil {
+			s.logger.Warn("kline replay: trade failed, continuing", zap.String("token", token.Symbol), zap.Error(err))
+			continue
+		}
+		trades = append(trades, *trade)
+	}
+	return trades, nil
+}
+
 // GetTrades returns all paper trades
 func (s *Service) GetTrades() []models.Trade {
 	s.mu.RLock()
@@ -196,24 +750,35 @@ func (s *Service) GetMetrics() models.Metrics {
 		metrics.AverageProfitPerTrade = totalProfit / float64(metrics.TotalTrades)
 	}
 
+	metrics.TotalBorrowed = s.totalBorrowed
+	metrics.TotalInterestPaid = s.totalInterestPaid
+	metrics.LiquidationCount = s.liquidationCount
+
 	return metrics
 }
 
-// Reset resets the paper trading portfolio
+// Reset resets the paper trading portfolio, including all open loans,
+// margin positions, and the lifetime borrowing counters.
 func (s *Service) Reset() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	s.portfolio = &models.Portfolio{
-		ID:            uuid.New().String(),
-		Balance:       s.initialBalance,
-		Currency:      "EUR",
-		ETHBalance:    s.initialBalance,
-		TokenBalances: make(map[string]models.TokenBalance),
-		TotalValue:    s.initialBalance,
-		UpdatedAt:     time.Now(),
+		ID:              uuid.New().String(),
+		Balance:         s.initialBalance,
+		Currency:        "EUR",
+		ETHBalance:      s.initialBalance,
+		TokenBalances:   make(map[string]models.TokenBalance),
+		Loans:           make(map[string]models.Loan),
+		MarginPositions: make(map[string]models.MarginPosition),
+		TotalValue:      s.initialBalance,
+		UpdatedAt:       s.clock.Now(),
 	}
 	s.trades = make([]models.Trade, 0)
+	s.totalBorrowed = 0
+	s.totalInterestPaid = 0
+	s.liquidationCount = 0
 
 	s.logger.Info("paper trading portfolio reset", zap.Float64("balance", s.initialBalance))
+	s.persist(nil)
 }
@@ -0,0 +1,213 @@
+package paper
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/twadelij/cryptojackal/internal/models"
+	"go.uber.org/zap"
+)
+
+const floatTolerance = 0.0001
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < floatTolerance
+}
+
+func TestLiquidationPrice(t *testing.T) {
+	tests := []struct {
+		name              string
+		size              float64
+		borrowed          float64
+		maintenanceMargin float64
+		want              float64
+	}{
+		{name: "2x leverage", size: 10, borrowed: 50, maintenanceMargin: 0.1, want: 50.0 / 9.0},
+		{name: "no borrow means no liquidation risk", size: 10, borrowed: 0, maintenanceMargin: 0.1, want: 0},
+		{name: "zero size guarded", size: 0, borrowed: 50, maintenanceMargin: 0.1, want: 0},
+		{name: "negative size guarded", size: -5, borrowed: 50, maintenanceMargin: 0.1, want: 0},
+		{name: "maintenance margin at 100% guarded", size: 10, borrowed: 50, maintenanceMargin: 1, want: 0},
+		{name: "maintenance margin over 100% guarded", size: 10, borrowed: 50, maintenanceMargin: 1.5, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := liquidationPrice(tt.size, tt.borrowed, tt.maintenanceMargin)
+			if !approxEqual(got, tt.want) {
+				t.Errorf("liquidationPrice(%v, %v, %v) = %v, want %v", tt.size, tt.borrowed, tt.maintenanceMargin, got, tt.want)
+			}
+		})
+	}
+}
+
+func newTestService(t *testing.T, initialBalance float64, clock *MockClock) *Service {
+	t.Helper()
+	cfg := MarginConfig{HourlyInterestRate: 0.01, MaintenanceMargin: 0.1}
+	return NewService(initialBalance, nil, nil, cfg, nil, clock, nil, zap.NewNop())
+}
+
+func TestRepayPartialInterestThenPrincipalSplit(t *testing.T) {
+	clock := NewMockClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	s := newTestService(t, 1000, clock)
+
+	loan, err := s.Borrow(100)
+	if err != nil {
+		t.Fatalf("Borrow: %v", err)
+	}
+
+	clock.Advance(10 * time.Hour)
+	got, err := s.Repay(loan.ID, 4)
+	if err != nil {
+		t.Fatalf("Repay: %v", err)
+	}
+	if !approxEqual(got.Principal, 100) {
+		t.Errorf("Principal after interest-only repayment = %v, want 100 (repayment should go to interest first)", got.Principal)
+	}
+	if !approxEqual(got.AccruedInterest, 6) {
+		t.Errorf("AccruedInterest after repaying 4 of 10 accrued = %v, want 6", got.AccruedInterest)
+	}
+
+	clock.Advance(10 * time.Hour)
+	got, err = s.Repay(loan.ID, 25)
+	if err != nil {
+		t.Fatalf("Repay: %v", err)
+	}
+	if !approxEqual(got.Principal, 95) {
+		t.Errorf("Principal after interest+principal repayment = %v, want 95", got.Principal)
+	}
+	if !approxEqual(got.AccruedInterest, 0) {
+		t.Errorf("AccruedInterest after fully covering it = %v, want 0", got.AccruedInterest)
+	}
+
+	if _, ok := s.portfolio.Loans[loan.ID]; !ok {
+		t.Errorf("loan with remaining principal should not have been deleted")
+	}
+}
+
+func TestExecuteTradeSettlesLoanOnFullClose(t *testing.T) {
+	clock := NewMockClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	s := newTestService(t, 1000, clock)
+	ctx := context.Background()
+	token := models.Token{Address: "0xtoken", Symbol: "TOK", Price: 10}
+
+	trade, err := s.ExecuteTrade(ctx, token, models.TradeTypeBuy, 10, 2)
+	if err != nil {
+		t.Fatalf("buy failed: %v", err)
+	}
+	pos, ok := s.portfolio.MarginPositions[token.Address]
+	if !ok {
+		t.Fatalf("expected an open margin position after leveraged buy")
+	}
+	loanID := pos.LoanID
+
+	clock.Advance(5 * time.Hour)
+	trade, err = s.ExecuteTrade(ctx, token, models.TradeTypeSell, 10, 1)
+	if err != nil {
+		t.Fatalf("sell failed: %v", err)
+	}
+	if trade.Status != models.TradeStatusExecuted {
+		t.Fatalf("sell trade status = %v, want executed", trade.Status)
+	}
+
+	if _, ok := s.portfolio.Loans[loanID]; ok {
+		t.Errorf("loan should be settled and removed after full close")
+	}
+	if _, ok := s.portfolio.MarginPositions[token.Address]; ok {
+		t.Errorf("margin position should be removed after full close")
+	}
+	// balance = 1000 - margin(50) + proceeds(100) - principal(50) - interest(50*0.01*5=2.5)
+	wantBalance := 1000.0 - 50 + 100 - 50 - 2.5
+	if !approxEqual(s.portfolio.Balance, wantBalance) {
+		t.Errorf("balance after settling loan = %v, want %v", s.portfolio.Balance, wantBalance)
+	}
+}
+
+func TestExecuteTradeMergesMarginPositionOnRepeatBuy(t *testing.T) {
+	clock := NewMockClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	s := newTestService(t, 1000, clock)
+	ctx := context.Background()
+	token := models.Token{Address: "0xtoken", Symbol: "TOK", Price: 10}
+
+	if _, err := s.ExecuteTrade(ctx, token, models.TradeTypeBuy, 10, 2); err != nil {
+		t.Fatalf("first buy failed: %v", err)
+	}
+	firstPos := s.portfolio.MarginPositions[token.Address]
+	firstLoanID := firstPos.LoanID
+
+	token.Price = 20
+	if _, err := s.ExecuteTrade(ctx, token, models.TradeTypeBuy, 10, 2); err != nil {
+		t.Fatalf("second buy failed: %v", err)
+	}
+
+	pos, ok := s.portfolio.MarginPositions[token.Address]
+	if !ok {
+		t.Fatalf("expected a single merged margin position")
+	}
+	if pos.LoanID != firstLoanID {
+		t.Errorf("second leveraged buy should reuse the existing loan, got a new one")
+	}
+	if !approxEqual(pos.Size, 20) {
+		t.Errorf("Size = %v, want 20 (10 + 10)", pos.Size)
+	}
+	if !approxEqual(pos.EntryPrice, 15) {
+		t.Errorf("EntryPrice = %v, want 15 (size-weighted average of 10 and 20)", pos.EntryPrice)
+	}
+
+	loan := s.portfolio.Loans[firstLoanID]
+	if !approxEqual(loan.Principal, 150) {
+		t.Errorf("merged loan Principal = %v, want 150 (50 from first buy + 100 from second)", loan.Principal)
+	}
+	wantLiquidation := liquidationPrice(20, 150, s.marginCfg.MaintenanceMargin)
+	if !approxEqual(pos.LiquidationPrice, wantLiquidation) {
+		t.Errorf("LiquidationPrice = %v, want %v (recomputed from combined size/principal)", pos.LiquidationPrice, wantLiquidation)
+	}
+}
+
+func TestExecuteTradePartialSellReducesMarginPosition(t *testing.T) {
+	clock := NewMockClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	s := newTestService(t, 1000, clock)
+	ctx := context.Background()
+	token := models.Token{Address: "0xtoken", Symbol: "TOK", Price: 10}
+
+	if _, err := s.ExecuteTrade(ctx, token, models.TradeTypeBuy, 10, 2); err != nil {
+		t.Fatalf("buy failed: %v", err)
+	}
+	pos := s.portfolio.MarginPositions[token.Address]
+	loanID := pos.LoanID
+
+	clock.Advance(10 * time.Hour)
+	if _, err := s.ExecuteTrade(ctx, token, models.TradeTypeSell, 2.5, 1); err != nil {
+		t.Fatalf("partial sell failed: %v", err)
+	}
+
+	pos, ok := s.portfolio.MarginPositions[token.Address]
+	if !ok {
+		t.Fatalf("partial sell should leave the margin position open")
+	}
+	if !approxEqual(pos.Size, 7.5) {
+		t.Errorf("Size after partial sell = %v, want 7.5 (10 - 2.5)", pos.Size)
+	}
+
+	loan, ok := s.portfolio.Loans[loanID]
+	if !ok {
+		t.Fatalf("loan should still be open after a partial sell")
+	}
+	// 25% of the position was sold, so 25% of the original 50 principal
+	// (12.5) should have been repaid, leaving 37.5.
+	if !approxEqual(loan.Principal, 37.5) {
+		t.Errorf("loan Principal after partial sell = %v, want 37.5 (75%% of 50)", loan.Principal)
+	}
+
+	wantLiquidation := liquidationPrice(7.5, 37.5, s.marginCfg.MaintenanceMargin)
+	if !approxEqual(pos.LiquidationPrice, wantLiquidation) {
+		t.Errorf("LiquidationPrice = %v, want %v (recomputed from reduced size/principal)", pos.LiquidationPrice, wantLiquidation)
+	}
+
+	// balance = 1000 - margin(50) + proceeds(2.5*10=25) - repaidPrincipal(12.5) - repaidInterest(50*0.01*10*0.25=1.25)
+	wantBalance := 1000.0 - 50 + 25 - 12.5 - 1.25
+	if !approxEqual(s.portfolio.Balance, wantBalance) {
+		t.Errorf("balance after partial sell = %v, want %v", s.portfolio.Balance, wantBalance)
+	}
+}
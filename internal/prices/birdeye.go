@@ -0,0 +1,83 @@
+package prices
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const birdeyeBaseURL = "https://public-api.birdeye.so"
+
+var birdeyeChains = map[string]string{
+	"ethereum": "ethereum",
+	"bsc":      "bsc",
+	"polygon":  "polygon",
+	"solana":   "solana",
+}
+
+// BirdeyeSource prices a token via the Birdeye API. Birdeye's strongest
+// coverage is Solana, but it also serves the major EVM chains.
+type BirdeyeSource struct {
+	httpClient *http.Client
+	apiKey     string
+	logger     *zap.Logger
+}
+
+// NewBirdeyeSource creates a BirdeyeSource. An empty apiKey makes GetPrice
+// always fail, mirroring the other API-key-gated sources.
+func NewBirdeyeSource(apiKey string, logger *zap.Logger) *BirdeyeSource {
+	return &BirdeyeSource{
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		apiKey:     apiKey,
+		logger:     logger,
+	}
+}
+
+func (s *BirdeyeSource) Name() string { return "birdeye" }
+
+func (s *BirdeyeSource) GetPrice(ctx context.Context, chain, address string) (*SourceQuote, error) {
+	if s.apiKey == "" {
+		return nil, fmt.Errorf("birdeye: API key not configured")
+	}
+	birdeyeChain, ok := birdeyeChains[chain]
+	if !ok {
+		return nil, fmt.Errorf("birdeye: chain %q not supported", chain)
+	}
+
+	endpoint := fmt.Sprintf("%s/defi/price?address=%s", birdeyeBaseURL, address)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-API-KEY", s.apiKey)
+	req.Header.Set("x-chain", birdeyeChain)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch token price: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("birdeye API error: %d", resp.StatusCode)
+	}
+
+	var data struct {
+		Success bool `json:"success"`
+		Data    struct {
+			Value float64 `json:"value"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if !data.Success {
+		return nil, fmt.Errorf("birdeye: request unsuccessful")
+	}
+
+	return &SourceQuote{Source: s.Name(), Price: data.Data.Value}, nil
+}
@@ -0,0 +1,87 @@
+package prices
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const geckoTerminalBaseURL = "https://api.geckoterminal.com/api/v2"
+
+// geckoTerminalNetworks maps our chain names to GeckoTerminal's network slugs.
+var geckoTerminalNetworks = map[string]string{
+	"ethereum": "eth",
+	"bsc":      "bsc",
+	"polygon":  "polygon_pos",
+	"solana":   "solana",
+}
+
+// GeckoTerminalSource prices a token via GeckoTerminal's on-chain indexer,
+// a source independent of CoinGeckoClient's own market-cap-ranked data.
+type GeckoTerminalSource struct {
+	httpClient *http.Client
+	logger     *zap.Logger
+}
+
+// NewGeckoTerminalSource creates a GeckoTerminalSource. The public API
+// requires no key.
+func NewGeckoTerminalSource(logger *zap.Logger) *GeckoTerminalSource {
+	return &GeckoTerminalSource{
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		logger:     logger,
+	}
+}
+
+func (s *GeckoTerminalSource) Name() string { return "geckoterminal" }
+
+func (s *GeckoTerminalSource) GetPrice(ctx context.Context, chain, address string) (*SourceQuote, error) {
+	network, ok := geckoTerminalNetworks[chain]
+	if !ok {
+		return nil, fmt.Errorf("geckoterminal: chain %q not supported", chain)
+	}
+
+	endpoint := fmt.Sprintf("%s/networks/%s/tokens/%s", geckoTerminalBaseURL, network, address)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch token price: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("geckoterminal API error: %d", resp.StatusCode)
+	}
+
+	var data struct {
+		Data struct {
+			Attributes struct {
+				PriceUSD  string `json:"price_usd"`
+				VolumeUSD struct {
+					H24 string `json:"h24"`
+				} `json:"volume_usd"`
+				TotalReserveUSD string `json:"total_reserve_in_usd"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	price, err := strconv.ParseFloat(data.Data.Attributes.PriceUSD, 64)
+	if err != nil {
+		return nil, fmt.Errorf("geckoterminal: unparseable price %q: %w", data.Data.Attributes.PriceUSD, err)
+	}
+	volume, _ := strconv.ParseFloat(data.Data.Attributes.VolumeUSD.H24, 64)
+	liquidity, _ := strconv.ParseFloat(data.Data.Attributes.TotalReserveUSD, 64)
+
+	return &SourceQuote{Source: s.Name(), Price: price, Liquidity: liquidity, Volume24h: volume}, nil
+}
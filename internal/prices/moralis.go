@@ -0,0 +1,74 @@
+package prices
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const moralisBaseURL = "https://deep-index.moralis.io/api/v2.2"
+
+var moralisChains = map[string]string{
+	"ethereum": "eth",
+	"bsc":      "bsc",
+	"polygon":  "polygon",
+}
+
+// MoralisSource prices a token via the Moralis Web3 Data API.
+type MoralisSource struct {
+	httpClient *http.Client
+	apiKey     string
+	logger     *zap.Logger
+}
+
+// NewMoralisSource creates a MoralisSource. An empty apiKey makes GetPrice
+// always fail, mirroring the other API-key-gated sources.
+func NewMoralisSource(apiKey string, logger *zap.Logger) *MoralisSource {
+	return &MoralisSource{
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		apiKey:     apiKey,
+		logger:     logger,
+	}
+}
+
+func (s *MoralisSource) Name() string { return "moralis" }
+
+func (s *MoralisSource) GetPrice(ctx context.Context, chain, address string) (*SourceQuote, error) {
+	if s.apiKey == "" {
+		return nil, fmt.Errorf("moralis: API key not configured")
+	}
+	moralisChain, ok := moralisChains[chain]
+	if !ok {
+		return nil, fmt.Errorf("moralis: chain %q not supported", chain)
+	}
+
+	endpoint := fmt.Sprintf("%s/erc20/%s/price?chain=%s", moralisBaseURL, address, moralisChain)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-API-Key", s.apiKey)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch token price: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("moralis API error: %d", resp.StatusCode)
+	}
+
+	var data struct {
+		USDPrice float64 `json:"usdPrice"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &SourceQuote{Source: s.Name(), Price: data.USDPrice}, nil
+}
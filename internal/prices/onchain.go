@@ -0,0 +1,199 @@
+package prices
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+var (
+	uniswapV2FactoryAddress = common.HexToAddress("0x5C69bEe701ef814a2B6a3EDD4B1652CB9cc5aA6f")
+	uniswapV3FactoryAddress = common.HexToAddress("0x1F98431c8aD98523631AE4a59f267346ea31F984")
+	wethAddress             = common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+)
+
+// v3Fees are the standard Uniswap V3 fee tiers, tried in order since a
+// token may only have liquidity on one of them.
+var v3Fees = []*big.Int{big.NewInt(500), big.NewInt(3000), big.NewInt(10000)}
+
+var v2FactoryABI = mustParseABI(`[{"name":"getPair","type":"function","stateMutability":"view","inputs":[{"name":"tokenA","type":"address"},{"name":"tokenB","type":"address"}],"outputs":[{"name":"pair","type":"address"}]}]`)
+
+var v2PairABI = mustParseABI(`[
+	{"name":"getReserves","type":"function","stateMutability":"view","inputs":[],"outputs":[{"name":"reserve0","type":"uint112"},{"name":"reserve1","type":"uint112"},{"name":"blockTimestampLast","type":"uint32"}]},
+	{"name":"token0","type":"function","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"address"}]}
+]`)
+
+var v3FactoryABI = mustParseABI(`[{"name":"getPool","type":"function","stateMutability":"view","inputs":[{"name":"tokenA","type":"address"},{"name":"tokenB","type":"address"},{"name":"fee","type":"uint24"}],"outputs":[{"name":"pool","type":"address"}]}]`)
+
+var v3PoolABI = mustParseABI(`[
+	{"name":"slot0","type":"function","stateMutability":"view","inputs":[],"outputs":[{"name":"sqrtPriceX96","type":"uint160"},{"name":"tick","type":"int24"},{"name":"observationIndex","type":"uint16"},{"name":"observationCardinality","type":"uint16"},{"name":"observationCardinalityNext","type":"uint16"},{"name":"feeProtocol","type":"uint8"},{"name":"unlocked","type":"bool"}]},
+	{"name":"token0","type":"function","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"address"}]}
+]`)
+
+func mustParseABI(raw string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(raw))
+	if err != nil {
+		panic(fmt.Sprintf("prices: invalid embedded ABI: %v", err))
+	}
+	return parsed
+}
+
+// OnChainReader prices a token directly from Uniswap V2 reserves or V3
+// slot0, bypassing any off-chain indexer and so immune to whatever
+// staleness or manipulation an indexer's cache can suffer from. Tokens are
+// assumed to pair against WETH and use the conventional 18 decimals.
+type OnChainReader struct {
+	client *ethclient.Client
+}
+
+// NewOnChainReader creates an OnChainReader. A nil client makes GetPrice
+// always fail, mirroring security.Analyzer's degrade-gracefully pattern
+// when no node URL is configured.
+func NewOnChainReader(client *ethclient.Client) *OnChainReader {
+	return &OnChainReader{client: client}
+}
+
+func (r *OnChainReader) Name() string { return "onchain" }
+
+func (r *OnChainReader) GetPrice(ctx context.Context, chain, address string) (*SourceQuote, error) {
+	if r.client == nil {
+		return nil, fmt.Errorf("onchain reader: no node configured")
+	}
+	if chain != "ethereum" {
+		return nil, fmt.Errorf("onchain reader: chain %q not supported", chain)
+	}
+
+	token := common.HexToAddress(address)
+
+	if price, liquidity, err := r.priceFromV2(ctx, token); err == nil {
+		return &SourceQuote{Source: r.Name(), Price: price, Liquidity: liquidity}, nil
+	}
+
+	for _, fee := range v3Fees {
+		if price, err := r.priceFromV3(ctx, token, fee); err == nil {
+			return &SourceQuote{Source: r.Name(), Price: price}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("onchain reader: no V2 pair or V3 pool found for %s", address)
+}
+
+func (r *OnChainReader) call(ctx context.Context, to common.Address, data []byte) ([]byte, error) {
+	return r.client.CallContract(ctx, ethereum.CallMsg{To: &to, Data: data}, nil)
+}
+
+// priceFromV2 reads a Uniswap V2 pair's reserves and returns the WETH
+// price of token plus the pair's WETH-side liquidity.
+func (r *OnChainReader) priceFromV2(ctx context.Context, token common.Address) (price, liquidityETH float64, err error) {
+	pairData, err := v2FactoryABI.Pack("getPair", token, wethAddress)
+	if err != nil {
+		return 0, 0, err
+	}
+	pairOut, err := r.call(ctx, uniswapV2FactoryAddress, pairData)
+	if err != nil {
+		return 0, 0, err
+	}
+	pairAddr := common.BytesToAddress(pairOut)
+	if pairAddr == (common.Address{}) {
+		return 0, 0, fmt.Errorf("no V2 pair")
+	}
+
+	token0Data, err := v2PairABI.Pack("token0")
+	if err != nil {
+		return 0, 0, err
+	}
+	token0Out, err := r.call(ctx, pairAddr, token0Data)
+	if err != nil {
+		return 0, 0, err
+	}
+	token0 := common.BytesToAddress(token0Out)
+
+	reservesData, err := v2PairABI.Pack("getReserves")
+	if err != nil {
+		return 0, 0, err
+	}
+	reservesOut, err := r.call(ctx, pairAddr, reservesData)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(reservesOut) < 64 {
+		return 0, 0, fmt.Errorf("short getReserves response")
+	}
+	var reserve0, reserve1 big.Int
+	reserve0.SetBytes(reservesOut[0:32])
+	reserve1.SetBytes(reservesOut[32:64])
+
+	reserveToken, reserveWETH := &reserve0, &reserve1
+	if token0 != token {
+		reserveToken, reserveWETH = &reserve1, &reserve0
+	}
+	if reserveToken.Sign() == 0 {
+		return 0, 0, fmt.Errorf("zero token reserve")
+	}
+
+	tokenReserveF, _ := new(big.Float).SetInt(reserveToken).Float64()
+	wethReserveF, _ := new(big.Float).SetInt(reserveWETH).Float64()
+	return wethReserveF / tokenReserveF, wethReserveF / 1e18, nil
+}
+
+// priceFromV3 reads a Uniswap V3 pool's slot0 sqrtPriceX96 for the given
+// fee tier and returns the WETH price of token.
+func (r *OnChainReader) priceFromV3(ctx context.Context, token common.Address, fee *big.Int) (float64, error) {
+	poolData, err := v3FactoryABI.Pack("getPool", token, wethAddress, fee)
+	if err != nil {
+		return 0, err
+	}
+	poolOut, err := r.call(ctx, uniswapV3FactoryAddress, poolData)
+	if err != nil {
+		return 0, err
+	}
+	poolAddr := common.BytesToAddress(poolOut)
+	if poolAddr == (common.Address{}) {
+		return 0, fmt.Errorf("no V3 pool for fee tier")
+	}
+
+	token0Data, err := v3PoolABI.Pack("token0")
+	if err != nil {
+		return 0, err
+	}
+	token0Out, err := r.call(ctx, poolAddr, token0Data)
+	if err != nil {
+		return 0, err
+	}
+	token0 := common.BytesToAddress(token0Out)
+
+	slot0Data, err := v3PoolABI.Pack("slot0")
+	if err != nil {
+		return 0, err
+	}
+	slot0Out, err := r.call(ctx, poolAddr, slot0Data)
+	if err != nil {
+		return 0, err
+	}
+	if len(slot0Out) < 32 {
+		return 0, fmt.Errorf("short slot0 response")
+	}
+	var sqrtPriceX96 big.Int
+	sqrtPriceX96.SetBytes(slot0Out[0:32])
+
+	sqrtPrice := new(big.Float).SetInt(&sqrtPriceX96)
+	q96 := new(big.Float).SetFloat64(math.Pow(2, 96))
+	ratio := new(big.Float).Quo(sqrtPrice, q96)
+	ratio.Mul(ratio, ratio)
+	priceToken1PerToken0, _ := ratio.Float64()
+
+	if token0 == token {
+		return priceToken1PerToken0, nil
+	}
+	if priceToken1PerToken0 == 0 {
+		return 0, fmt.Errorf("zero price")
+	}
+	return 1 / priceToken1PerToken0, nil
+}
@@ -0,0 +1,190 @@
+// Package prices fans out to multiple independent price sources for a
+// token and reconciles their results into a single median-backed quote,
+// rejecting outliers whose deviation from the median is too large to be
+// explained by ordinary quoting noise.
+package prices
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// SourceQuote is a single provider's best-effort price for a token.
+type SourceQuote struct {
+	Source    string  `json:"source"`
+	Price     float64 `json:"price"`
+	Liquidity float64 `json:"liquidity,omitempty"`
+	Volume24h float64 `json:"volume_24h,omitempty"`
+}
+
+// PriceSource prices a single token on a single provider.
+type PriceSource interface {
+	Name() string
+	GetPrice(ctx context.Context, chain, address string) (*SourceQuote, error)
+}
+
+// SourceContribution records one source's result (or failure) and how it
+// factored into the reconciled median.
+type SourceContribution struct {
+	Source   string  `json:"source"`
+	Price    float64 `json:"price,omitempty"`
+	ZScore   float64 `json:"z_score,omitempty"`
+	Included bool    `json:"included"`
+	Error    string  `json:"error,omitempty"`
+}
+
+// PriceQuote is the reconciled result across every configured source.
+type PriceQuote struct {
+	Chain       string               `json:"chain"`
+	Address     string               `json:"address"`
+	MedianPrice float64              `json:"median_price"`
+	Dispersion  float64              `json:"dispersion"` // coefficient of variation across included sources
+	Sources     []SourceContribution `json:"sources"`
+	GeneratedAt time.Time            `json:"generated_at"`
+}
+
+// Reconciler fans out to every configured PriceSource concurrently and
+// reconciles their results into a single PriceQuote.
+type Reconciler struct {
+	sources         []PriceSource
+	zScoreThreshold float64
+	warnDispersion  float64
+	logger          *zap.Logger
+}
+
+// NewReconciler creates a Reconciler. zScoreThreshold bounds how far a
+// source's price may sit from the median (in standard deviations) before
+// it's excluded from the reconciled price; warnDispersion is the
+// coefficient-of-variation (stddev/mean) across included sources above
+// which a warning is logged, since high dispersion even among "included"
+// sources can itself signal a stale cache or a thinly-traded pool.
+func NewReconciler(sources []PriceSource, zScoreThreshold, warnDispersion float64, logger *zap.Logger) *Reconciler {
+	return &Reconciler{
+		sources:         sources,
+		zScoreThreshold: zScoreThreshold,
+		warnDispersion:  warnDispersion,
+		logger:          logger,
+	}
+}
+
+// Reconcile queries every source for (chain, address) concurrently,
+// computes the median and each source's Z-score against it, and returns a
+// PriceQuote whose MedianPrice is recomputed from only the sources within
+// zScoreThreshold of the first-pass median.
+func (r *Reconciler) Reconcile(ctx context.Context, chain, address string) (*PriceQuote, error) {
+	if len(r.sources) == 0 {
+		return nil, fmt.Errorf("prices: no sources configured")
+	}
+
+	type result struct {
+		source string
+		quote  *SourceQuote
+		err    error
+	}
+
+	results := make(chan result, len(r.sources))
+	for _, src := range r.sources {
+		src := src
+		go func() {
+			q, err := src.GetPrice(ctx, chain, address)
+			results <- result{source: src.Name(), quote: q, err: err}
+		}()
+	}
+
+	raw := make([]result, 0, len(r.sources))
+	var samples []float64
+	for i := 0; i < len(r.sources); i++ {
+		res := <-results
+		raw = append(raw, res)
+		if res.err == nil && res.quote != nil && res.quote.Price > 0 {
+			samples = append(samples, res.quote.Price)
+		}
+	}
+
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("prices: every source failed for %s on %s", address, chain)
+	}
+
+	firstPassMedian := median(samples)
+	firstPassStdDev := stdDev(samples, firstPassMedian)
+
+	contributions := make([]SourceContribution, 0, len(raw))
+	var included []float64
+	for _, res := range raw {
+		if res.err != nil {
+			contributions = append(contributions, SourceContribution{Source: res.source, Error: res.err.Error()})
+			continue
+		}
+
+		z := 0.0
+		if firstPassStdDev > 0 {
+			z = (res.quote.Price - firstPassMedian) / firstPassStdDev
+		}
+		isIncluded := math.Abs(z) <= r.zScoreThreshold
+		if isIncluded {
+			included = append(included, res.quote.Price)
+		}
+		contributions = append(contributions, SourceContribution{
+			Source:   res.source,
+			Price:    res.quote.Price,
+			ZScore:   z,
+			Included: isIncluded,
+		})
+	}
+
+	reconciledMedian := median(included)
+	dispersion := 0.0
+	var includedSum float64
+	for _, p := range included {
+		includedSum += p
+	}
+	if len(included) > 0 && includedSum != 0 {
+		includedMean := includedSum / float64(len(included))
+		dispersion = stdDev(included, includedMean) / includedMean
+	}
+
+	if dispersion > r.warnDispersion {
+		r.logger.Warn("prices: reconciliation dispersion above threshold, possible stale cache or manipulated pool",
+			zap.String("address", address),
+			zap.Float64("dispersion", dispersion),
+		)
+	}
+
+	return &PriceQuote{
+		Chain:       chain,
+		Address:     address,
+		MedianPrice: reconciledMedian,
+		Dispersion:  dispersion,
+		Sources:     contributions,
+		GeneratedAt: time.Now(),
+	}, nil
+}
+
+func median(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), xs...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+func stdDev(xs []float64, mean float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	var sumSq float64
+	for _, x := range xs {
+		sumSq += (x - mean) * (x - mean)
+	}
+	return math.Sqrt(sumSq / float64(len(xs)))
+}
@@ -0,0 +1,71 @@
+package prices
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a minimal fixed-interval token bucket (capacity 1) so a
+// sweep or a busy trending scan can't blow through a provider's free-tier
+// request quota.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	last     time.Time
+}
+
+func newRateLimiter(requestsPerMinute int) *rateLimiter {
+	if requestsPerMinute <= 0 {
+		return nil
+	}
+	return &rateLimiter{interval: time.Minute / time.Duration(requestsPerMinute)}
+}
+
+// wait blocks until the next request is allowed to proceed, or ctx is
+// cancelled first. A nil receiver never blocks.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delay := time.Until(l.last.Add(l.interval))
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	l.last = time.Now()
+	return nil
+}
+
+// RateLimited wraps a PriceSource so it waits for its configured rate
+// limit before each request, rather than hammering a provider until it
+// starts returning errors.
+type RateLimited struct {
+	PriceSource
+	limiter *rateLimiter
+}
+
+// WithRateLimit returns src unchanged if requestsPerMinute <= 0, otherwise
+// wraps it with a rate limiter enforcing that cap.
+func WithRateLimit(src PriceSource, requestsPerMinute int) PriceSource {
+	if requestsPerMinute <= 0 {
+		return src
+	}
+	return &RateLimited{PriceSource: src, limiter: newRateLimiter(requestsPerMinute)}
+}
+
+func (r *RateLimited) GetPrice(ctx context.Context, chain, address string) (*SourceQuote, error) {
+	if err := r.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	return r.PriceSource.GetPrice(ctx, chain, address)
+}
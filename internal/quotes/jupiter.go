@@ -0,0 +1,75 @@
+package quotes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const jupiterBaseURL = "https://quote-api.jup.ag/v6"
+
+// JupiterAggregator quotes swaps through Jupiter, the Solana DEX
+// aggregator. It's the only aggregator in this package that targets a
+// non-EVM chain.
+type JupiterAggregator struct {
+	httpClient *http.Client
+	logger     *zap.Logger
+}
+
+// NewJupiterAggregator creates a new Jupiter-backed Aggregator.
+func NewJupiterAggregator(logger *zap.Logger) *JupiterAggregator {
+	return &JupiterAggregator{
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		logger:     logger,
+	}
+}
+
+func (j *JupiterAggregator) Name() string { return "jupiter" }
+
+type jupiterQuoteResponse struct {
+	OutAmount    string `json:"outAmount"`
+	PriceImpactPct string `json:"priceImpactPct"`
+}
+
+// Quote fetches a swap quote from GET /quote.
+func (j *JupiterAggregator) Quote(ctx context.Context, tokenIn, tokenOut string, amountIn float64) (*Quote, error) {
+	endpoint := fmt.Sprintf("%s/quote?inputMint=%s&outputMint=%s&amount=%d&slippageBps=50",
+		jupiterBaseURL, tokenIn, tokenOut, int64(amountIn*1e9)) // lamports (9 decimals)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := j.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("jupiter: failed to fetch quote: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jupiter API error: %d", resp.StatusCode)
+	}
+
+	var data jupiterQuoteResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("jupiter: failed to decode response: %w", err)
+	}
+
+	outAmount, _ := strconv.ParseFloat(data.OutAmount, 64)
+	priceImpact, _ := strconv.ParseFloat(data.PriceImpactPct, 64)
+
+	return &Quote{
+		Source:      j.Name(),
+		TokenIn:     tokenIn,
+		TokenOut:    tokenOut,
+		AmountIn:    amountIn,
+		AmountOut:   outAmount / 1e9,
+		PriceImpact: priceImpact / 100,
+	}, nil
+}
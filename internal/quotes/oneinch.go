@@ -0,0 +1,80 @@
+package quotes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const oneInchBaseURL = "https://api.1inch.dev"
+
+// OneInchAggregator quotes swaps through the 1inch aggregation protocol.
+type OneInchAggregator struct {
+	httpClient *http.Client
+	apiKey     string
+	chainID    int64
+	logger     *zap.Logger
+}
+
+// NewOneInchAggregator creates a new 1inch-backed Aggregator for chainID.
+func NewOneInchAggregator(apiKey string, chainID int64, logger *zap.Logger) *OneInchAggregator {
+	return &OneInchAggregator{
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		apiKey:     apiKey,
+		chainID:    chainID,
+		logger:     logger,
+	}
+}
+
+func (o *OneInchAggregator) Name() string { return "1inch" }
+
+type oneInchQuoteResponse struct {
+	ToAmount string `json:"toAmount"`
+	Gas      int64  `json:"gas"`
+}
+
+// Quote fetches a swap quote from GET /swap/v6.0/{chainId}/quote.
+func (o *OneInchAggregator) Quote(ctx context.Context, tokenIn, tokenOut string, amountIn float64) (*Quote, error) {
+	endpoint := fmt.Sprintf("%s/swap/v6.0/%d/quote?src=%s&dst=%s&amount=%d",
+		oneInchBaseURL, o.chainID, tokenIn, tokenOut, int64(amountIn*1e18))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if o.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+o.apiKey)
+	}
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("1inch: failed to fetch quote: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("1inch API error: %d", resp.StatusCode)
+	}
+
+	var data oneInchQuoteResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("1inch: failed to decode response: %w", err)
+	}
+
+	toAmount, _ := strconv.ParseFloat(data.ToAmount, 64)
+
+	return &Quote{
+		Source:    o.Name(),
+		TokenIn:   tokenIn,
+		TokenOut:  tokenOut,
+		AmountIn:  amountIn,
+		AmountOut: toAmount / 1e18,
+		// 1inch's quote endpoint doesn't return calldata, so To and Data
+		// are left empty; they must be fetched separately from /swap.
+	}, nil
+}
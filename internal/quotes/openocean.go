@@ -0,0 +1,78 @@
+package quotes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const openOceanBaseURL = "https://open-api.openocean.finance/v3"
+
+// OpenOceanAggregator quotes swaps through OpenOcean, used here mainly as
+// a fallback on chains where 0x/1inch coverage is thin (BSC, Polygon, etc).
+type OpenOceanAggregator struct {
+	httpClient *http.Client
+	chain      string
+	logger     *zap.Logger
+}
+
+// NewOpenOceanAggregator creates a new OpenOcean-backed Aggregator for the
+// given chain slug (e.g. "eth", "bsc", "polygon").
+func NewOpenOceanAggregator(chain string, logger *zap.Logger) *OpenOceanAggregator {
+	return &OpenOceanAggregator{
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		chain:      chain,
+		logger:     logger,
+	}
+}
+
+func (o *OpenOceanAggregator) Name() string { return "openocean" }
+
+type openOceanQuoteResponse struct {
+	Data struct {
+		OutAmount   string  `json:"outAmount"`
+		EstimatedGas string `json:"estimatedGas"`
+		PriceImpact string  `json:"priceImpact"`
+	} `json:"data"`
+}
+
+// Quote fetches a swap quote from GET /{chain}/quote.
+func (o *OpenOceanAggregator) Quote(ctx context.Context, tokenIn, tokenOut string, amountIn float64) (*Quote, error) {
+	endpoint := fmt.Sprintf("%s/%s/quote?inTokenAddress=%s&outTokenAddress=%s&amount=%f",
+		openOceanBaseURL, o.chain, tokenIn, tokenOut, amountIn)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("openocean: failed to fetch quote: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("openocean API error: %d", resp.StatusCode)
+	}
+
+	var data openOceanQuoteResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("openocean: failed to decode response: %w", err)
+	}
+
+	var outAmount float64
+	fmt.Sscanf(data.Data.OutAmount, "%f", &outAmount)
+
+	return &Quote{
+		Source:    o.Name(),
+		TokenIn:   tokenIn,
+		TokenOut:  tokenOut,
+		AmountIn:  amountIn,
+		AmountOut: outAmount,
+	}, nil
+}
@@ -0,0 +1,159 @@
+package quotes
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// Quote is a single aggregator's best-effort price for swapping amountIn
+// of tokenIn for tokenOut.
+type Quote struct {
+	Source       string  `json:"source"`
+	TokenIn      string  `json:"token_in"`
+	TokenOut     string  `json:"token_out"`
+	AmountIn     float64 `json:"amount_in"`
+	AmountOut    float64 `json:"amount_out"`
+	PriceImpact  float64 `json:"price_impact"` // fraction, e.g. 0.012 = 1.2%
+	GasEstimateUSD float64 `json:"gas_estimate_usd"` // 0x fills this in ETH, not USD; the other aggregators leave it 0
+	To           string  `json:"to"`   // contract to send the swap transaction to
+	Data         string  `json:"data"` // calldata for the swap, hex-encoded
+}
+
+// Aggregator quotes a swap through a single DEX aggregator.
+type Aggregator interface {
+	Name() string
+	Quote(ctx context.Context, tokenIn, tokenOut string, amountIn float64) (*Quote, error)
+}
+
+// RoundTrip is the result of quoting a buy followed immediately by a sell
+// of the amount received, used to estimate real slippage and net PnL
+// rather than a flat price-impact guess.
+type RoundTrip struct {
+	Buy            *Quote  `json:"buy"`
+	Sell           *Quote  `json:"sell"`
+	NetAmountOut   float64 `json:"net_amount_out"`   // amountIn-denominated tokens received back after buy+sell
+	EffectiveSlippage float64 `json:"effective_slippage"` // fraction lost across the round trip
+	GasCostUSD     float64 `json:"gas_cost_usd"`
+}
+
+// nativeTokenAddresses maps a chain to the wrapped-native token address
+// used as the "in" leg when pricing a buy of an arbitrary token.
+var nativeTokenAddresses = map[string]string{
+	"ethereum": "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", // WETH
+	"bsc":      "0xbb4CdB9CBd36B01bD1cBaEBF2De08d9173bc095", // WBNB
+	"polygon":  "0x0d500B1d8E8eF31E21C99d1Db9A6444d3ADf1270", // WMATIC
+	"solana":   "So11111111111111111111111111111111111111112",
+}
+
+// NativeTokenAddress returns the wrapped-native token address for chain,
+// or an empty string if the chain isn't recognized.
+func NativeTokenAddress(chain string) string {
+	return nativeTokenAddresses[chain]
+}
+
+// Router chooses an aggregator per chain and exposes a "best across
+// enabled aggregators" quote.
+type Router struct {
+	byChain map[string][]Aggregator
+	logger  *zap.Logger
+}
+
+// NewRouter builds a Router from the given per-chain aggregator lists.
+func NewRouter(byChain map[string][]Aggregator, logger *zap.Logger) *Router {
+	return &Router{byChain: byChain, logger: logger}
+}
+
+// aggregatorsFor returns the aggregators enabled for chain, or an error if
+// none are configured.
+func (r *Router) aggregatorsFor(chain string) ([]Aggregator, error) {
+	aggs, ok := r.byChain[chain]
+	if !ok || len(aggs) == 0 {
+		return nil, fmt.Errorf("no quote aggregators configured for chain %q", chain)
+	}
+	return aggs, nil
+}
+
+// Best queries every aggregator enabled for chain concurrently and
+// returns the one with the highest amountOut.
+func (r *Router) Best(ctx context.Context, chain, tokenIn, tokenOut string, amountIn float64) (*Quote, error) {
+	aggs, err := r.aggregatorsFor(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		quote *Quote
+		err   error
+	}
+	results := make(chan result, len(aggs))
+	for _, agg := range aggs {
+		agg := agg
+		go func() {
+			q, err := agg.Quote(ctx, tokenIn, tokenOut, amountIn)
+			results <- result{quote: q, err: err}
+		}()
+	}
+
+	var best *Quote
+	for i := 0; i < len(aggs); i++ {
+		res := <-results
+		if res.err != nil {
+			r.logger.Warn("aggregator quote failed", zap.Error(res.err))
+			continue
+		}
+		if best == nil || res.quote.AmountOut > best.AmountOut {
+			best = res.quote
+		}
+	}
+
+	if best == nil {
+		return nil, fmt.Errorf("all quote aggregators failed for %s -> %s on %s", tokenIn, tokenOut, chain)
+	}
+	return best, nil
+}
+
+// ByName returns the named aggregator for chain, used when the caller
+// (e.g. ExecuteTrade) wants to route through a specific aggregator
+// instead of the best-of-all result.
+func (r *Router) ByName(chain, name string) (Aggregator, error) {
+	aggs, err := r.aggregatorsFor(chain)
+	if err != nil {
+		return nil, err
+	}
+	for _, agg := range aggs {
+		if agg.Name() == name {
+			return agg, nil
+		}
+	}
+	return nil, fmt.Errorf("aggregator %q not enabled for chain %q", name, chain)
+}
+
+// RoundTripQuote buys tokenOut with amountIn of tokenIn, then quotes
+// immediately selling the received amount back, to measure real
+// round-trip slippage and gas cost instead of a flat estimate.
+func (r *Router) RoundTripQuote(ctx context.Context, chain, tokenIn, tokenOut string, amountIn float64) (*RoundTrip, error) {
+	buy, err := r.Best(ctx, chain, tokenIn, tokenOut, amountIn)
+	if err != nil {
+		return nil, fmt.Errorf("buy leg failed: %w", err)
+	}
+
+	sell, err := r.Best(ctx, chain, tokenOut, tokenIn, buy.AmountOut)
+	if err != nil {
+		return nil, fmt.Errorf("sell leg failed: %w", err)
+	}
+
+	slippage := 0.0
+	if amountIn > 0 {
+		slippage = (amountIn - sell.AmountOut) / amountIn
+	}
+
+	return &RoundTrip{
+		Buy:               buy,
+		Sell:              sell,
+		NetAmountOut:      sell.AmountOut,
+		EffectiveSlippage: slippage,
+		GasCostUSD:        buy.GasEstimateUSD + sell.GasEstimateUSD,
+	}, nil
+}
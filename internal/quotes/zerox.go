@@ -0,0 +1,87 @@
+package quotes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const zeroXBaseURL = "https://api.0x.org"
+
+// ZeroXAggregator quotes swaps through the 0x API.
+type ZeroXAggregator struct {
+	httpClient *http.Client
+	apiKey     string
+	logger     *zap.Logger
+}
+
+// NewZeroXAggregator creates a new 0x-backed Aggregator.
+func NewZeroXAggregator(apiKey string, logger *zap.Logger) *ZeroXAggregator {
+	return &ZeroXAggregator{
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		apiKey:     apiKey,
+		logger:     logger,
+	}
+}
+
+func (z *ZeroXAggregator) Name() string { return "0x" }
+
+type zeroXQuoteResponse struct {
+	BuyAmount    string `json:"buyAmount"`
+	EstimatedPriceImpact string `json:"estimatedPriceImpact"`
+	GasPrice     string `json:"gasPrice"`
+	EstimatedGas string `json:"estimatedGas"`
+	To           string `json:"to"`
+	Data         string `json:"data"`
+}
+
+// Quote fetches a swap quote from GET /swap/v1/quote.
+func (z *ZeroXAggregator) Quote(ctx context.Context, tokenIn, tokenOut string, amountIn float64) (*Quote, error) {
+	endpoint := fmt.Sprintf("%s/swap/v1/quote?sellToken=%s&buyToken=%s&sellAmount=%d",
+		zeroXBaseURL, tokenIn, tokenOut, int64(amountIn*1e18))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if z.apiKey != "" {
+		req.Header.Set("0x-api-key", z.apiKey)
+	}
+
+	resp, err := z.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("0x: failed to fetch quote: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("0x API error: %d", resp.StatusCode)
+	}
+
+	var data zeroXQuoteResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("0x: failed to decode response: %w", err)
+	}
+
+	buyAmount, _ := strconv.ParseFloat(data.BuyAmount, 64)
+	priceImpact, _ := strconv.ParseFloat(data.EstimatedPriceImpact, 64)
+	gasUsed, _ := strconv.ParseFloat(data.EstimatedGas, 64)
+	gasPriceWei, _ := strconv.ParseFloat(data.GasPrice, 64)
+
+	return &Quote{
+		Source:         z.Name(),
+		TokenIn:        tokenIn,
+		TokenOut:       tokenOut,
+		AmountIn:       amountIn,
+		AmountOut:      buyAmount / 1e18,
+		PriceImpact:    priceImpact,
+		GasEstimateUSD: gasUsed * gasPriceWei / 1e18, // in ETH; no USD conversion is applied
+		To:             data.To,
+		Data:           data.Data,
+	}, nil
+}
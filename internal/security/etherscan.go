@@ -0,0 +1,117 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const etherscanBaseURL = "https://api.etherscan.io/api"
+
+// dangerousSignatures are function selectors/names commonly used by
+// contracts to rug their holders after launch.
+var dangerousSignatures = []string{"mint", "blacklist", "setFee", "pause", "excludeFromFee", "setMaxTx"}
+
+// verificationResult is the outcome of an Etherscan-style source lookup.
+type verificationResult struct {
+	Verified            bool
+	OwnerRenounced       bool
+	DangerousSignatures []string
+	Reason              string
+}
+
+// etherscanClient fetches contract source verification status and owner
+// information from an Etherscan-compatible block explorer API.
+type etherscanClient struct {
+	httpClient *http.Client
+	apiKey     string
+	logger     *zap.Logger
+}
+
+func newEtherscanClient(apiKey string, logger *zap.Logger) *etherscanClient {
+	return &etherscanClient{
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		apiKey:     apiKey,
+		logger:     logger,
+	}
+}
+
+type etherscanSourceResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Result  []struct {
+		SourceCode string `json:"SourceCode"`
+		ABI        string `json:"ABI"`
+		ContractName string `json:"ContractName"`
+	} `json:"result"`
+}
+
+// VerificationStatus reports whether the contract source is verified and
+// scans the verified source code (not the ABI or bytecode) for dangerous
+// owner-only functions. chain is not used: every lookup goes to the
+// Ethereum mainnet Etherscan API. A missing API key returns an unverified
+// result carrying only a Reason, with no error.
+func (c *etherscanClient) VerificationStatus(ctx context.Context, chain, address string) (*verificationResult, error) {
+	if c.apiKey == "" {
+		return &verificationResult{Reason: "etherscan API key not configured, skipped"}, nil
+	}
+
+	endpoint := fmt.Sprintf("%s?module=contract&action=getsourcecode&address=%s&apikey=%s", etherscanBaseURL, address, c.apiKey)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch contract source: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("etherscan API error: %d", resp.StatusCode)
+	}
+
+	var data etherscanSourceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(data.Result) == 0 {
+		return &verificationResult{Reason: "contract not found on explorer"}, nil
+	}
+
+	src := data.Result[0]
+	result := &verificationResult{
+		Verified: src.SourceCode != "",
+	}
+	if !result.Verified {
+		result.Reason = "contract source is not verified"
+	}
+
+	lowerSrc := strings.ToLower(src.SourceCode)
+	for _, sig := range dangerousSignatures {
+		if strings.Contains(lowerSrc, strings.ToLower(sig)+"(") {
+			result.DangerousSignatures = append(result.DangerousSignatures, sig)
+		}
+	}
+
+	// A renounced owner typically transfers ownership to the zero address;
+	// verified source containing "renounceOwnership" alone isn't proof, so
+	// we treat the absence of an active owner() override as a weak signal.
+	result.OwnerRenounced = strings.Contains(lowerSrc, "renounceownership") && !strings.Contains(lowerSrc, "function owner()")
+
+	c.logger.Debug("etherscan verification checked",
+		zap.String("address", address),
+		zap.Bool("verified", result.Verified),
+		zap.Int("dangerous_signatures", len(result.DangerousSignatures)),
+	)
+
+	return result, nil
+}
@@ -0,0 +1,218 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// maxAcceptableTaxPct is the transfer tax above which a token is flagged
+// as a hard-fail security risk rather than just a soft warning.
+const maxAcceptableTaxPct = 10.0
+
+// Well-known router and LP-locker addresses used for the honeypot
+// simulation and the LP-lock check. Kept as Ethereum mainnet defaults;
+// a future multi-chain pass should make these configurable per chain.
+var (
+	uniswapV2RouterAddress  = common.HexToAddress("0x7a250d5630B4cF539739dF2C5dAcb4c659F2488D")
+	uniswapV2FactoryAddress = common.HexToAddress("0x5C69bEe701ef814a2B6a3EDD4B1652CB9cc5aA6f")
+	wethAddress             = common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	burnAddresses           = []common.Address{
+		common.HexToAddress("0x000000000000000000000000000000000000dEaD"),
+		common.HexToAddress("0x0000000000000000000000000000000000000000"),
+	}
+	// Known LP-locker contracts (Unicrypt, Team.Finance) whose holding an
+	// LP token counts as "locked" rather than held by a single EOA.
+	knownLockers = []common.Address{
+		common.HexToAddress("0x17e00383A843A9922bCA3B280C0ADE9f8BA48449"), // Unicrypt V2 locker
+		common.HexToAddress("0xE2fE530C047f2d85298b07D9333C05737f1435fB"), // Team.Finance locker
+	}
+)
+
+var routerABI = mustParseABI(`[
+	{"name":"swapExactETHForTokens","type":"function","stateMutability":"payable",
+	 "inputs":[{"name":"amountOutMin","type":"uint256"},{"name":"path","type":"address[]"},{"name":"to","type":"address"},{"name":"deadline","type":"uint256"}],
+	 "outputs":[{"name":"amounts","type":"uint256[]"}]},
+	{"name":"swapExactTokensForETH","type":"function","stateMutability":"nonpayable",
+	 "inputs":[{"name":"amountIn","type":"uint256"},{"name":"amountOutMin","type":"uint256"},{"name":"path","type":"address[]"},{"name":"to","type":"address"},{"name":"deadline","type":"uint256"}],
+	 "outputs":[{"name":"amounts","type":"uint256[]"}]}
+]`)
+
+var erc20ABI = mustParseABI(`[
+	{"name":"balanceOf","type":"function","stateMutability":"view",
+	 "inputs":[{"name":"account","type":"address"}],"outputs":[{"name":"","type":"uint256"}]}
+]`)
+
+var factoryABI = mustParseABI(`[
+	{"name":"getPair","type":"function","stateMutability":"view",
+	 "inputs":[{"name":"tokenA","type":"address"},{"name":"tokenB","type":"address"}],
+	 "outputs":[{"name":"pair","type":"address"}]}
+]`)
+
+func mustParseABI(raw string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(raw))
+	if err != nil {
+		panic(fmt.Sprintf("security: invalid embedded ABI: %v", err))
+	}
+	return parsed
+}
+
+type simulationResult struct {
+	Honeypot       bool
+	TransferTaxPct float64
+	Reason         string
+}
+
+// callArg mirrors the JSON shape ethclient.Client builds internally for
+// eth_call (see go-ethereum's ethclient.toCallArg), which this package
+// can't reuse since it's unexported.
+type callArg struct {
+	From  common.Address  `json:"from,omitempty"`
+	To    *common.Address `json:"to,omitempty"`
+	Value *hexutil.Big    `json:"value,omitempty"`
+	Data  hexutil.Bytes   `json:"data,omitempty"`
+}
+
+// overrideAccount is one entry of the eth_call state override set geth
+// supports as call's optional third parameter: see
+// https://geth.ethereum.org/docs/interacting-with-geth/rpc/ns-eth#eth_call.
+type overrideAccount struct {
+	Balance *hexutil.Big `json:"balance,omitempty"`
+}
+
+// callContractWithBalanceOverride performs an eth_call identical to
+// ethclient.Client.CallContract, except it first credits addr with
+// balance for the duration of the call via eth_call's state override
+// parameter. ethclient.Client has no override support, so this talks to
+// the node's JSON-RPC directly through the client's underlying rpc.Client.
+// Needed to simulate a buy from simulatedBuyer, which holds no real ETH:
+// without the override, the EVM's balance check rejects the call before
+// the swap logic ever runs, which CallContract has no way to tell apart
+// from the token itself reverting the trade.
+func callContractWithBalanceOverride(ctx context.Context, client *ethclient.Client, call ethereum.CallMsg, addr common.Address, balance *big.Int) ([]byte, error) {
+	arg := callArg{From: call.From, To: call.To, Data: call.Data}
+	if call.Value != nil {
+		arg.Value = (*hexutil.Big)(call.Value)
+	}
+	overrides := map[common.Address]overrideAccount{
+		addr: {Balance: (*hexutil.Big)(balance)},
+	}
+
+	var result hexutil.Bytes
+	if err := client.Client().CallContext(ctx, &result, "eth_call", arg, "latest", overrides); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// simulateBuySell performs a read-only eth_call round trip: a hypothetical
+// buy of the token through the Uniswap V2 router followed by an immediate
+// sell of the resulting balance. A sell that reverts indicates a
+// honeypot; the amount the sell would return is not checked, and
+// TransferTaxPct is never set.
+func simulateBuySell(ctx context.Context, client *ethclient.Client, tokenAddress string) (*simulationResult, error) {
+	token := common.HexToAddress(tokenAddress)
+	simulatedBuyer := common.HexToAddress("0x000000000000000000000000000000000000B1") // arbitrary EOA, never broadcast
+
+	amountIn := new(big.Int).Mul(big.NewInt(1), big.NewInt(1e17)) // 0.1 ETH notional
+	deadline := big.NewInt(9_999_999_999)
+
+	buyData, err := routerABI.Pack("swapExactETHForTokens", big.NewInt(0), []common.Address{wethAddress, token}, simulatedBuyer, deadline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode buy calldata: %w", err)
+	}
+
+	// simulatedBuyer is a throwaway address with no real ETH, so fund it
+	// for the duration of this call via a state override rather than
+	// letting the EVM's balance check reject the swap before it runs.
+	buyerFunding := new(big.Int).Mul(amountIn, big.NewInt(2))
+	_, err = callContractWithBalanceOverride(ctx, client, ethereum.CallMsg{
+		From:  simulatedBuyer,
+		To:    &uniswapV2RouterAddress,
+		Value: amountIn,
+		Data:  buyData,
+	}, simulatedBuyer, buyerFunding)
+	if err != nil {
+		return &simulationResult{Honeypot: true, Reason: fmt.Sprintf("buy simulation reverted: %v", err)}, nil
+	}
+
+	balanceData, err := erc20ABI.Pack("balanceOf", simulatedBuyer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode balanceOf calldata: %w", err)
+	}
+	balanceOut, err := client.CallContract(ctx, ethereum.CallMsg{To: &token, Data: balanceData}, nil)
+	if err != nil {
+		return &simulationResult{Honeypot: true, Reason: fmt.Sprintf("post-buy balance check failed: %v", err)}, nil
+	}
+	var simulatedBalance big.Int
+	simulatedBalance.SetBytes(balanceOut)
+	if simulatedBalance.Sign() == 0 {
+		return &simulationResult{Honeypot: true, Reason: "buy simulation returned zero tokens"}, nil
+	}
+
+	sellData, err := routerABI.Pack("swapExactTokensForETH", &simulatedBalance, big.NewInt(0), []common.Address{token, wethAddress}, simulatedBuyer, deadline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode sell calldata: %w", err)
+	}
+	if _, err := client.CallContract(ctx, ethereum.CallMsg{From: simulatedBuyer, To: &uniswapV2RouterAddress, Data: sellData}, nil); err != nil {
+		return &simulationResult{Honeypot: true, Reason: fmt.Sprintf("sell simulation reverted: %v", err)}, nil
+	}
+
+	return &simulationResult{Honeypot: false}, nil
+}
+
+type lpLockResult struct {
+	Locked bool
+	Reason string
+}
+
+// checkLPLock resolves the Uniswap V2 pair contract for token/WETH, then
+// inspects that pair's own LP token holder set and reports whether the
+// majority of supply sits in a burn address or a known locker contract
+// rather than a single EOA that could rug the pool. The pair token, not
+// the project token, is what's actually minted and held as LP supply.
+func checkLPLock(ctx context.Context, client *ethclient.Client, tokenAddress string) (*lpLockResult, error) {
+	token := common.HexToAddress(tokenAddress)
+
+	pairData, err := factoryABI.Pack("getPair", token, wethAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode getPair calldata: %w", err)
+	}
+	pairOut, err := client.CallContract(ctx, ethereum.CallMsg{To: &uniswapV2FactoryAddress, Data: pairData}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve LP pair address: %w", err)
+	}
+	values, err := factoryABI.Unpack("getPair", pairOut)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode getPair result: %w", err)
+	}
+	pair, ok := values[0].(common.Address)
+	if !ok || pair == (common.Address{}) {
+		return &lpLockResult{Locked: false, Reason: "no Uniswap V2 pair exists for this token/WETH"}, nil
+	}
+
+	for _, holder := range append(append([]common.Address{}, burnAddresses...), knownLockers...) {
+		data, err := erc20ABI.Pack("balanceOf", holder)
+		if err != nil {
+			continue
+		}
+		out, err := client.CallContract(ctx, ethereum.CallMsg{To: &pair, Data: data}, nil)
+		if err != nil {
+			continue
+		}
+		var balance big.Int
+		balance.SetBytes(out)
+		if balance.Sign() > 0 {
+			return &lpLockResult{Locked: true, Reason: "LP supply held by a burn address or known locker"}, nil
+		}
+	}
+
+	return &lpLockResult{Locked: false, Reason: "LP supply not found in burn addresses or known lockers"}, nil
+}
@@ -0,0 +1,225 @@
+package security
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"go.uber.org/zap"
+)
+
+// SecurityAnalyzer screens a token contract for rug-pull / honeypot risk.
+type SecurityAnalyzer interface {
+	Analyze(ctx context.Context, chain, address string) (*Report, error)
+}
+
+// Check is a single pass/fail test that contributes to a Report.
+type Check struct {
+	Name   string  `json:"name"`
+	Passed bool    `json:"passed"`
+	Hard   bool    `json:"hard"` // hard checks, when failed, disqualify the token outright
+	Reason string  `json:"reason,omitempty"`
+	Weight float64 `json:"weight"`
+}
+
+// Report is the composed result of all security checks for a token.
+type Report struct {
+	Address     string    `json:"address"`
+	Chain       string    `json:"chain"`
+	Score       float64   `json:"score"` // 0-1, higher is safer
+	Honeypot    bool      `json:"honeypot"`
+	Verified    bool      `json:"verified"`
+	OwnerRenounced bool   `json:"owner_renounced"`
+	LPLocked    bool      `json:"lp_locked"`
+	Checks      []Check   `json:"checks"`
+	Reasons     []string  `json:"reasons"`
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// FailsHardChecks reports whether the token should be excluded from
+// opportunity scanning outright, regardless of its overall score.
+func (r *Report) FailsHardChecks() bool {
+	for _, c := range r.Checks {
+		if c.Hard && !c.Passed {
+			return true
+		}
+	}
+	return false
+}
+
+type cacheEntry struct {
+	report *Report
+	expiry time.Time
+}
+
+// Analyzer is the default SecurityAnalyzer, combining an on-chain
+// honeypot/ownership simulation with an Etherscan-style verification
+// lookup and an LP-lock check.
+type Analyzer struct {
+	client    *ethclient.Client
+	etherscan *etherscanClient
+	logger    *zap.Logger
+
+	mu    sync.RWMutex
+	cache map[string]cacheEntry
+	ttl   time.Duration
+}
+
+// NewAnalyzer creates a new Analyzer. nodeURL and etherscanAPIKey are both
+// optional: when nodeURL is empty the on-chain checks are skipped, and when
+// etherscanAPIKey is empty the verification/owner checks are skipped. This
+// mirrors how wallet.Wallet degrades gracefully when not fully configured.
+func NewAnalyzer(nodeURL, etherscanAPIKey string, logger *zap.Logger) *Analyzer {
+	a := &Analyzer{
+		etherscan: newEtherscanClient(etherscanAPIKey, logger),
+		logger:    logger,
+		cache:     make(map[string]cacheEntry),
+		ttl:       15 * time.Minute,
+	}
+
+	if nodeURL != "" {
+		client, err := ethclient.Dial(nodeURL)
+		if err != nil {
+			logger.Warn("security analyzer: on-chain checks disabled, failed to dial node", zap.Error(err))
+		} else {
+			a.client = client
+		}
+	}
+
+	return a
+}
+
+// Analyze runs all configured checks for a token and returns a cached
+// report when a fresh one already exists.
+func (a *Analyzer) Analyze(ctx context.Context, chain, address string) (*Report, error) {
+	if report, ok := a.cached(address); ok {
+		return report, nil
+	}
+
+	report := &Report{
+		Address:     address,
+		Chain:       chain,
+		GeneratedAt: time.Now(),
+	}
+
+	checks := make([]Check, 0, 6)
+
+	verification, err := a.etherscan.VerificationStatus(ctx, chain, address)
+	if err != nil {
+		a.logger.Warn("security: verification lookup failed", zap.String("address", address), zap.Error(err))
+	} else if a.etherscan.apiKey != "" {
+		report.Verified = verification.Verified
+		report.OwnerRenounced = verification.OwnerRenounced
+		checks = append(checks,
+			Check{Name: "contract_verified", Passed: verification.Verified, Hard: true, Weight: 0.2, Reason: verification.Reason},
+			Check{Name: "owner_renounced", Passed: verification.OwnerRenounced, Hard: false, Weight: 0.15},
+		)
+		for _, sig := range verification.DangerousSignatures {
+			checks = append(checks, Check{
+				Name:   "bytecode_signature:" + sig,
+				Passed: false,
+				Hard:   false,
+				Weight: 0.1,
+				Reason: "contract exposes a " + sig + " function",
+			})
+		}
+	}
+
+	if a.client != nil {
+		sim, err := simulateBuySell(ctx, a.client, address)
+		if err != nil {
+			a.logger.Warn("security: buy/sell simulation failed", zap.String("address", address), zap.Error(err))
+		} else {
+			report.Honeypot = sim.Honeypot
+			checks = append(checks, Check{
+				Name:   "sell_simulation",
+				Passed: !sim.Honeypot,
+				Hard:   true,
+				Weight: 0.3,
+				Reason: sim.Reason,
+			})
+			if sim.TransferTaxPct > 0 {
+				checks = append(checks, Check{
+					Name:   "transfer_tax",
+					Passed: sim.TransferTaxPct <= maxAcceptableTaxPct,
+					Hard:   sim.TransferTaxPct > maxAcceptableTaxPct,
+					Weight: 0.15,
+					Reason: "transfer tax estimated at a percentage of the trade",
+				})
+			}
+		}
+
+		lp, err := checkLPLock(ctx, a.client, address)
+		if err != nil {
+			a.logger.Warn("security: LP lock check failed", zap.String("address", address), zap.Error(err))
+		} else {
+			report.LPLocked = lp.Locked
+			checks = append(checks, Check{
+				Name:   "lp_locked_or_burned",
+				Passed: lp.Locked,
+				Hard:   false,
+				Weight: 0.1,
+				Reason: lp.Reason,
+			})
+		}
+	}
+
+	report.Checks = checks
+	report.Score, report.Reasons = scoreChecks(checks)
+
+	a.store(address, report)
+	return report, nil
+}
+
+func (a *Analyzer) cached(address string) (*Report, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	entry, ok := a.cache[address]
+	if !ok || time.Now().After(entry.expiry) {
+		return nil, false
+	}
+	return entry.report, true
+}
+
+func (a *Analyzer) store(address string, report *Report) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.cache[address] = cacheEntry{report: report, expiry: time.Now().Add(a.ttl)}
+}
+
+// scoreChecks composes a weighted 0-1 score and a human-readable list of
+// reasons from the individual checks. Failed hard checks cap the score.
+func scoreChecks(checks []Check) (float64, []string) {
+	if len(checks) == 0 {
+		return 0.5, nil
+	}
+
+	var totalWeight, earned float64
+	reasons := make([]string, 0)
+	hardFailed := false
+
+	for _, c := range checks {
+		totalWeight += c.Weight
+		if c.Passed {
+			earned += c.Weight
+		} else {
+			if c.Hard {
+				hardFailed = true
+			}
+			if c.Reason != "" {
+				reasons = append(reasons, c.Reason)
+			}
+		}
+	}
+
+	score := 0.5
+	if totalWeight > 0 {
+		score = earned / totalWeight
+	}
+	if hardFailed && score > 0.2 {
+		score = 0.2
+	}
+
+	return score, reasons
+}
@@ -0,0 +1,265 @@
+// Package signals computes technical indicators incrementally as new
+// candlesticks close, and exposes a registry so callers can plug in
+// custom indicators alongside the built-ins.
+package signals
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/twadelij/cryptojackal/internal/marketdata"
+)
+
+// EMA is an incrementally-updated exponential moving average.
+type EMA struct {
+	Period      int
+	value       float64
+	initialized bool
+}
+
+// NewEMA creates an EMA over the given period.
+func NewEMA(period int) *EMA {
+	return &EMA{Period: period}
+}
+
+// Update feeds in the next closing price and returns the updated average.
+func (e *EMA) Update(price float64) float64 {
+	if !e.initialized {
+		e.value = price
+		e.initialized = true
+		return e.value
+	}
+	k := 2.0 / (float64(e.Period) + 1.0)
+	e.value = price*k + e.value*(1-k)
+	return e.value
+}
+
+// Value returns the current average without updating it.
+func (e *EMA) Value() float64 { return e.value }
+
+// RSI is an incrementally-updated relative strength index using Wilder's
+// smoothing method.
+type RSI struct {
+	Period      int
+	avgGain     float64
+	avgLoss     float64
+	prevClose   float64
+	initialized bool
+	value       float64
+}
+
+// NewRSI creates an RSI over the given period (14 is the conventional default).
+func NewRSI(period int) *RSI {
+	return &RSI{Period: period}
+}
+
+// Update feeds in the next closing price and returns the updated RSI (0-100).
+func (r *RSI) Update(price float64) float64 {
+	if !r.initialized {
+		r.prevClose = price
+		r.initialized = true
+		r.value = 50
+		return r.value
+	}
+
+	change := price - r.prevClose
+	r.prevClose = price
+
+	var gain, loss float64
+	if change > 0 {
+		gain = change
+	} else {
+		loss = -change
+	}
+
+	n := float64(r.Period)
+	r.avgGain = (r.avgGain*(n-1) + gain) / n
+	r.avgLoss = (r.avgLoss*(n-1) + loss) / n
+
+	if r.avgLoss == 0 {
+		r.value = 100
+		return r.value
+	}
+
+	rs := r.avgGain / r.avgLoss
+	r.value = 100 - (100 / (1 + rs))
+	return r.value
+}
+
+// Value returns the current RSI without updating it.
+func (r *RSI) Value() float64 { return r.value }
+
+// MACD is an incrementally-updated moving-average-convergence-divergence
+// indicator with its own signal-line EMA.
+type MACD struct {
+	fast   *EMA
+	slow   *EMA
+	signal *EMA
+
+	MACDLine  float64
+	Signal    float64
+	Histogram float64
+}
+
+// NewMACD creates a MACD with the conventional 12/26/9 periods.
+func NewMACD() *MACD {
+	return &MACD{
+		fast:   NewEMA(12),
+		slow:   NewEMA(26),
+		signal: NewEMA(9),
+	}
+}
+
+// Update feeds in the next closing price and returns (macd, signal, histogram).
+func (m *MACD) Update(price float64) (float64, float64, float64) {
+	fast := m.fast.Update(price)
+	slow := m.slow.Update(price)
+	m.MACDLine = fast - slow
+	m.Signal = m.signal.Update(m.MACDLine)
+	m.Histogram = m.MACDLine - m.Signal
+	return m.MACDLine, m.Signal, m.Histogram
+}
+
+// BollingerBands tracks a simple moving average and standard deviation
+// over a rolling window of closing prices.
+type BollingerBands struct {
+	Period    int
+	NumStdDev float64
+	prices    []float64
+
+	Middle float64
+	Upper  float64
+	Lower  float64
+}
+
+// NewBollingerBands creates Bollinger Bands over period closes with
+// bands numStdDev standard deviations from the mean.
+func NewBollingerBands(period int, numStdDev float64) *BollingerBands {
+	return &BollingerBands{Period: period, NumStdDev: numStdDev}
+}
+
+// Update feeds in the next closing price and returns (middle, upper, lower).
+func (b *BollingerBands) Update(price float64) (float64, float64, float64) {
+	b.prices = append(b.prices, price)
+	if len(b.prices) > b.Period {
+		b.prices = b.prices[len(b.prices)-b.Period:]
+	}
+
+	var sum float64
+	for _, p := range b.prices {
+		sum += p
+	}
+	mean := sum / float64(len(b.prices))
+
+	var variance float64
+	for _, p := range b.prices {
+		variance += (p - mean) * (p - mean)
+	}
+	variance /= float64(len(b.prices))
+	stdDev := math.Sqrt(variance)
+
+	b.Middle = mean
+	b.Upper = mean + b.NumStdDev*stdDev
+	b.Lower = mean - b.NumStdDev*stdDev
+	return b.Middle, b.Upper, b.Lower
+}
+
+// ATR is an incrementally-updated average true range using Wilder's
+// smoothing method, computed from full OHLC bars rather than closes alone.
+type ATR struct {
+	Period      int
+	prevClose   float64
+	initialized bool
+	value       float64
+}
+
+// NewATR creates an ATR over the given period (14 is the conventional default).
+func NewATR(period int) *ATR {
+	return &ATR{Period: period}
+}
+
+// Update feeds in the next bar and returns the updated ATR.
+func (a *ATR) Update(bar marketdata.Bar) float64 {
+	trueRange := bar.High - bar.Low
+	if a.initialized {
+		trueRange = math.Max(trueRange, math.Max(math.Abs(bar.High-a.prevClose), math.Abs(bar.Low-a.prevClose)))
+	}
+	a.prevClose = bar.Close
+
+	if !a.initialized {
+		a.value = trueRange
+		a.initialized = true
+		return a.value
+	}
+
+	n := float64(a.Period)
+	a.value = (a.value*(n-1) + trueRange) / n
+	return a.value
+}
+
+// Value returns the current ATR without updating it.
+func (a *ATR) Value() float64 { return a.value }
+
+// Snapshot is a single evaluation of every indicator for one series of bars.
+type Snapshot struct {
+	EMA20       float64
+	RSI14       float64
+	MACDLine    float64
+	MACDSignal  float64
+	MACDHist    float64
+	BollMiddle  float64
+	BollUpper   float64
+	BollLower   float64
+	ATR14       float64
+	Custom      map[string]float64
+}
+
+// CustomFunc computes a user-defined indicator value from a bar series.
+type CustomFunc func(bars []marketdata.Bar) float64
+
+// Registry holds the built-in indicator set plus any user-registered
+// custom indicator functions, and evaluates all of them over a bar series.
+type Registry struct {
+	custom map[string]CustomFunc
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{custom: make(map[string]CustomFunc)}
+}
+
+// Register adds a custom indicator function under name, overwriting any
+// existing registration with that name.
+func (r *Registry) Register(name string, fn CustomFunc) {
+	r.custom[name] = fn
+}
+
+// Evaluate replays bars through the built-in indicators plus every
+// registered custom function and returns a single Snapshot of their
+// final values.
+func (r *Registry) Evaluate(bars []marketdata.Bar) (*Snapshot, error) {
+	if len(bars) == 0 {
+		return nil, fmt.Errorf("signals: cannot evaluate an empty bar series")
+	}
+
+	ema20 := NewEMA(20)
+	rsi14 := NewRSI(14)
+	macd := NewMACD()
+	boll := NewBollingerBands(20, 2)
+	atr14 := NewATR(14)
+
+	snap := &Snapshot{Custom: make(map[string]float64, len(r.custom))}
+	for _, bar := range bars {
+		snap.EMA20 = ema20.Update(bar.Close)
+		snap.RSI14 = rsi14.Update(bar.Close)
+		snap.MACDLine, snap.MACDSignal, snap.MACDHist = macd.Update(bar.Close)
+		snap.BollMiddle, snap.BollUpper, snap.BollLower = boll.Update(bar.Close)
+		snap.ATR14 = atr14.Update(bar)
+	}
+
+	for name, fn := range r.custom {
+		snap.Custom[name] = fn(bars)
+	}
+
+	return snap, nil
+}
@@ -0,0 +1,241 @@
+//go:build !postgres
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	"github.com/twadelij/cryptojackal/internal/models"
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore is the default Store backend: a single local file, no
+// external database server required.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens (creating if necessary) a SQLite database at path
+// and applies the schema.
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite database: %w", err)
+	}
+	// SQLite serializes writes internally; a single connection avoids
+	// "database is locked" errors under concurrent access.
+	db.SetMaxOpenConns(1)
+
+	s := &SQLiteStore{db: db}
+	if err := s.applySchema(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// applySchema brings the database up to the latest embedded migration via
+// golang-migrate's sqlite3 driver, wrapping the already-open *sql.DB.
+func (s *SQLiteStore) applySchema() error {
+	driver, err := sqlite3.WithInstance(s.db, &sqlite3.Config{})
+	if err != nil {
+		return fmt.Errorf("init sqlite migration driver: %w", err)
+	}
+	return runMigrations(driver)
+}
+
+// Close closes the underlying database connection.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+// SaveTrade upserts a trade record.
+func (s *SQLiteStore) SaveTrade(ctx context.Context, trade models.Trade) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO trades (id, token_address, token_symbol, type, amount_in, amount_out, price, gas_used, gas_price, tx_hash, status, profit_loss, executed_at, is_paper_trade)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			status = excluded.status,
+			profit_loss = excluded.profit_loss,
+			amount_out = excluded.amount_out,
+			tx_hash = excluded.tx_hash
+	`, trade.ID, trade.TokenAddress, trade.TokenSymbol, string(trade.Type), trade.AmountIn, trade.AmountOut, trade.Price, trade.GasUsed, trade.GasPrice, trade.TxHash, string(trade.Status), trade.ProfitLoss, trade.ExecutedAt, trade.IsPaperTrade)
+	if err != nil {
+		return fmt.Errorf("save trade: %w", err)
+	}
+	return nil
+}
+
+// LoadTrades returns trades matching filter, most recently executed first.
+func (s *SQLiteStore) LoadTrades(ctx context.Context, filter TradeFilter) ([]models.Trade, error) {
+	query := strings.Builder{}
+	query.WriteString("SELECT id, token_address, token_symbol, type, amount_in, amount_out, price, gas_used, gas_price, tx_hash, status, profit_loss, executed_at, is_paper_trade FROM trades WHERE 1=1")
+	args := make([]interface{}, 0, 3)
+
+	if !filter.Since.IsZero() {
+		query.WriteString(" AND executed_at >= ?")
+		args = append(args, filter.Since)
+	}
+	if filter.TokenAddress != "" {
+		query.WriteString(" AND token_address = ?")
+		args = append(args, filter.TokenAddress)
+	}
+	query.WriteString(" ORDER BY executed_at DESC")
+	if filter.Limit > 0 {
+		query.WriteString(" LIMIT ?")
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("load trades: %w", err)
+	}
+	defer rows.Close()
+
+	trades := make([]models.Trade, 0)
+	for rows.Next() {
+		var t models.Trade
+		var tradeType, status string
+		if err := rows.Scan(&t.ID, &t.TokenAddress, &t.TokenSymbol, &tradeType, &t.AmountIn, &t.AmountOut, &t.Price, &t.GasUsed, &t.GasPrice, &t.TxHash, &status, &t.ProfitLoss, &t.ExecutedAt, &t.IsPaperTrade); err != nil {
+			return nil, fmt.Errorf("scan trade: %w", err)
+		}
+		t.Type = models.TradeType(tradeType)
+		t.Status = models.TradeStatus(status)
+		trades = append(trades, t)
+	}
+	return trades, rows.Err()
+}
+
+// SavePortfolio upserts the single-row portfolio snapshot. Token balances,
+// loans and margin positions are stored as JSON since they're maps, not
+// tables of their own.
+func (s *SQLiteStore) SavePortfolio(ctx context.Context, portfolio *models.Portfolio) error {
+	tokenBalances, err := json.Marshal(portfolio.TokenBalances)
+	if err != nil {
+		return fmt.Errorf("marshal token balances: %w", err)
+	}
+	loans, err := json.Marshal(portfolio.Loans)
+	if err != nil {
+		return fmt.Errorf("marshal loans: %w", err)
+	}
+	marginPositions, err := json.Marshal(portfolio.MarginPositions)
+	if err != nil {
+		return fmt.Errorf("marshal margin positions: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO portfolio (id, balance, currency, token_balances, loans, margin_positions, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			balance = excluded.balance,
+			currency = excluded.currency,
+			token_balances = excluded.token_balances,
+			loans = excluded.loans,
+			margin_positions = excluded.margin_positions,
+			updated_at = excluded.updated_at
+	`, portfolio.ID, portfolio.Balance, portfolio.Currency, string(tokenBalances), string(loans), string(marginPositions), portfolio.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("save portfolio: %w", err)
+	}
+	return nil
+}
+
+// LoadPortfolio returns the most recently updated portfolio snapshot, or
+// nil if none has ever been saved.
+func (s *SQLiteStore) LoadPortfolio(ctx context.Context) (*models.Portfolio, error) {
+	row := s.db.QueryRowContext(ctx, `
+		SELECT id, balance, currency, token_balances, loans, margin_positions, updated_at
+		FROM portfolio ORDER BY updated_at DESC LIMIT 1
+	`)
+
+	var p models.Portfolio
+	var tokenBalances, loans, marginPositions string
+	if err := row.Scan(&p.ID, &p.Balance, &p.Currency, &tokenBalances, &loans, &marginPositions, &p.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("load portfolio: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(tokenBalances), &p.TokenBalances); err != nil {
+		return nil, fmt.Errorf("unmarshal token balances: %w", err)
+	}
+	if err := json.Unmarshal([]byte(loans), &p.Loans); err != nil {
+		return nil, fmt.Errorf("unmarshal loans: %w", err)
+	}
+	if err := json.Unmarshal([]byte(marginPositions), &p.MarginPositions); err != nil {
+		return nil, fmt.Errorf("unmarshal margin positions: %w", err)
+	}
+	return &p, nil
+}
+
+// UpsertToken records or refreshes a discovered token.
+func (s *SQLiteStore) UpsertToken(ctx context.Context, token models.Token) error {
+	tags, err := json.Marshal(token.Tags)
+	if err != nil {
+		return fmt.Errorf("marshal tags: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO tokens (address, symbol, name, decimals, price, price_change_24h, market_cap, volume_24h, liquidity, security_score, discovered_at, tags)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(address) DO UPDATE SET
+			symbol = excluded.symbol,
+			name = excluded.name,
+			price = excluded.price,
+			price_change_24h = excluded.price_change_24h,
+			market_cap = excluded.market_cap,
+			volume_24h = excluded.volume_24h,
+			liquidity = excluded.liquidity,
+			security_score = excluded.security_score,
+			tags = excluded.tags
+	`, token.Address, token.Symbol, token.Name, token.Decimals, token.Price, token.PriceChange24h, token.MarketCap, token.Volume24h, token.Liquidity, token.SecurityScore, token.DiscoveredAt, string(tags))
+	if err != nil {
+		return fmt.Errorf("upsert token: %w", err)
+	}
+	return nil
+}
+
+// QueryTokens returns tokens matching filter.
+func (s *SQLiteStore) QueryTokens(ctx context.Context, filter TokenFilter) ([]models.Token, error) {
+	query := strings.Builder{}
+	query.WriteString("SELECT address, symbol, name, decimals, price, price_change_24h, market_cap, volume_24h, liquidity, security_score, discovered_at, tags FROM tokens WHERE liquidity >= ?")
+	args := []interface{}{filter.MinLiquidity}
+
+	if filter.Tag != "" {
+		query.WriteString(" AND tags LIKE ?")
+		args = append(args, "%\""+filter.Tag+"\"%")
+	}
+	query.WriteString(" ORDER BY discovered_at DESC")
+	if filter.Limit > 0 {
+		query.WriteString(" LIMIT ?")
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("query tokens: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := make([]models.Token, 0)
+	for rows.Next() {
+		var t models.Token
+		var tags string
+		if err := rows.Scan(&t.Address, &t.Symbol, &t.Name, &t.Decimals, &t.Price, &t.PriceChange24h, &t.MarketCap, &t.Volume24h, &t.Liquidity, &t.SecurityScore, &t.DiscoveredAt, &tags); err != nil {
+			return nil, fmt.Errorf("scan token: %w", err)
+		}
+		if err := json.Unmarshal([]byte(tags), &t.Tags); err != nil {
+			return nil, fmt.Errorf("unmarshal tags: %w", err)
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens, rows.Err()
+}
+
+var _ Store = (*SQLiteStore)(nil)
@@ -0,0 +1,78 @@
+// Package storage persists portfolio state, trade history, and discovered
+// tokens so a restart doesn't wipe the bot's memory. The default build
+// targets SQLite (sqlite.go); build with the "postgres" tag to link the
+// Postgres-backed implementation (postgres.go) instead.
+package storage
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/twadelij/cryptojackal/internal/models"
+)
+
+// migrationFiles embeds the versioned schema migrations both backends
+// apply on startup. See migrations/ for the actual SQL: every primary key
+// is an application-generated string (a uuid or a token address), so no
+// migration needs backend-specific auto-increment syntax, and DOUBLE
+// PRECISION / TIMESTAMP / BOOLEAN are understood by both the SQLite and
+// Postgres drivers.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// TradeFilter narrows LoadTrades. A zero value matches every trade, most
+// recent first.
+type TradeFilter struct {
+	Since        time.Time
+	TokenAddress string
+	Limit        int
+}
+
+// TokenFilter narrows QueryTokens. A zero value matches every token.
+type TokenFilter struct {
+	Tag          string
+	MinLiquidity float64
+	Limit        int
+}
+
+// Store is the persistence boundary for everything paper.Service and
+// discovery.Service would otherwise only keep in memory. Implementations
+// must be safe for concurrent use.
+type Store interface {
+	SaveTrade(ctx context.Context, trade models.Trade) error
+	LoadTrades(ctx context.Context, filter TradeFilter) ([]models.Trade, error)
+
+	SavePortfolio(ctx context.Context, portfolio *models.Portfolio) error
+	LoadPortfolio(ctx context.Context) (*models.Portfolio, error)
+
+	UpsertToken(ctx context.Context, token models.Token) error
+	QueryTokens(ctx context.Context, filter TokenFilter) ([]models.Token, error)
+
+	Close() error
+}
+
+// runMigrations applies every pending migration embedded in
+// migrationFiles to dbDriver using golang-migrate, which records applied
+// versions in the database's own schema_migrations table so a migration
+// never reapplies and a later column addition/rename only needs a new
+// numbered .sql file, not an edit to an already-shipped one.
+func runMigrations(dbDriver database.Driver) error {
+	src, err := iofs.New(migrationFiles, "migrations")
+	if err != nil {
+		return fmt.Errorf("load embedded migrations: %w", err)
+	}
+	m, err := migrate.NewWithInstance("iofs", src, "cryptojackal", dbDriver)
+	if err != nil {
+		return fmt.Errorf("init migrator: %w", err)
+	}
+	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+		return fmt.Errorf("apply migrations: %w", err)
+	}
+	return nil
+}
@@ -2,30 +2,50 @@ package trading
 
 import (
 	"context"
+	"fmt"
+	"math/big"
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/twadelij/cryptojackal/internal/config"
 	"github.com/twadelij/cryptojackal/internal/discovery"
+	"github.com/twadelij/cryptojackal/internal/events"
+	"github.com/twadelij/cryptojackal/internal/halt"
 	"github.com/twadelij/cryptojackal/internal/models"
 	"github.com/twadelij/cryptojackal/internal/paper"
+	"github.com/twadelij/cryptojackal/internal/quotes"
+	"github.com/twadelij/cryptojackal/internal/storage"
 	"github.com/twadelij/cryptojackal/internal/wallet"
+	"github.com/twadelij/cryptojackal/internal/wallet/contracts"
 	"go.uber.org/zap"
 )
 
+// weiFromETH converts an ETH-denominated float amount to wei.
+func weiFromETH(amount float64) *big.Int {
+	wei := new(big.Float).Mul(big.NewFloat(amount), big.NewFloat(1e18))
+	result, _ := wei.Int(nil)
+	return result
+}
+
 // Engine is the main trading engine
 type Engine struct {
 	config       *config.Config
 	wallet       *wallet.Wallet
 	discovery    *discovery.Service
 	paper        *paper.Service
+	halt         *halt.Manager
+	events       *events.Bus
+	store        storage.Store
+	recorder     *Recorder
 	logger       *zap.Logger
 
 	mu           sync.RWMutex
 	isRunning    bool
 	startedAt    *time.Time
 	stopChan     chan struct{}
-	
+
 	// Stats
 	totalTrades      int
 	profitableTrades int
@@ -33,16 +53,46 @@ type Engine struct {
 	opportunities    []models.TradingOpportunity
 }
 
-// NewEngine creates a new trading engine
-func NewEngine(cfg *config.Config, w *wallet.Wallet, disc *discovery.Service, paperSvc *paper.Service, logger *zap.Logger) *Engine {
-	return &Engine{
+// NewEngine creates a new trading engine. bus is optional; when nil,
+// newly found opportunities simply aren't published anywhere. haltMgr is
+// optional; when nil, ExecuteTrade never rejects a trade on the
+// breaker's account and GetStatus always reports halted=false. store is
+// optional; when set, the engine's trade stats are seeded from persisted
+// history on startup instead of starting at zero every restart.
+func NewEngine(cfg *config.Config, w *wallet.Wallet, disc *discovery.Service, paperSvc *paper.Service, haltMgr *halt.Manager, bus *events.Bus, store storage.Store, logger *zap.Logger) *Engine {
+	e := &Engine{
 		config:    cfg,
 		wallet:    w,
 		discovery: disc,
 		paper:     paperSvc,
+		halt:      haltMgr,
+		events:    bus,
+		store:     store,
 		logger:    logger,
 		stopChan:  make(chan struct{}),
 	}
+	if store != nil {
+		e.loadStats()
+	}
+	return e
+}
+
+// loadStats seeds totalTrades/profitableTrades/totalProfitLoss from the
+// persisted trade history so a restart doesn't reset the engine's stats
+// to zero.
+func (e *Engine) loadStats() {
+	trades, err := e.store.LoadTrades(context.Background(), storage.TradeFilter{})
+	if err != nil {
+		e.logger.Warn("failed to load trade history from store", zap.Error(err))
+		return
+	}
+	for _, t := range trades {
+		e.totalTrades++
+		e.totalProfitLoss += t.ProfitLoss
+		if t.ProfitLoss > 0 {
+			e.profitableTrades++
+		}
+	}
 }
 
 // Start starts the trading engine
@@ -107,7 +157,7 @@ func (e *Engine) GetStatus() models.BotStatus {
 		balance, _ = e.wallet.GetBalanceETH(ctx)
 	}
 
-	return models.BotStatus{
+	status := models.BotStatus{
 		IsRunning:           e.isRunning,
 		Mode:                mode,
 		StartedAt:           e.startedAt,
@@ -117,6 +167,13 @@ func (e *Engine) GetStatus() models.BotStatus {
 		CurrentBalance:      balance,
 		ActiveOpportunities: len(e.opportunities),
 	}
+	if e.halt != nil {
+		haltStatus := e.halt.Status()
+		status.Halted = haltStatus.Halted
+		status.HaltTrigger = string(haltStatus.Trigger)
+		status.HaltReason = haltStatus.Reason
+	}
+	return status
 }
 
 // GetOpportunities returns current trading opportunities
@@ -126,13 +183,112 @@ func (e *Engine) GetOpportunities() []models.TradingOpportunity {
 	return e.opportunities
 }
 
-// ExecuteTrade manually executes a trade
-func (e *Engine) ExecuteTrade(ctx context.Context, opportunity models.TradingOpportunity, amount float64) (*models.Trade, error) {
+// SetRecorder wires r to capture every future scan() result, so a live
+// run can be replayed later via Engine.Replay. Pass nil to stop
+// recording.
+func (e *Engine) SetRecorder(r *Recorder) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.recorder = r
+}
+
+// ExecuteTrade manually executes a trade. aggregator, when non-empty,
+// routes the live-trading path through that specific DEX aggregator
+// instead of the best-of-all quote; it has no effect in paper mode.
+func (e *Engine) ExecuteTrade(ctx context.Context, opportunity models.TradingOpportunity, amount float64, aggregator string) (*models.Trade, error) {
+	if e.halt != nil {
+		if haltedNow, reason := e.halt.Check(); haltedNow {
+			trade := models.NewTrade(opportunity.Token.Address, opportunity.Token.Symbol, models.TradeTypeBuy, amount, opportunity.Token.Price, true)
+			trade.Status = models.TradeStatusRejected
+			return trade, fmt.Errorf("trading halted: %s", reason)
+		}
+	}
+
 	if e.config.PaperTradingMode {
-		return e.paper.ExecuteTrade(ctx, opportunity.Token, models.TradeTypeBuy, amount)
+		return e.paper.ExecuteTrade(ctx, opportunity.Token, models.TradeTypeBuy, amount, 1)
+	}
+
+	if e.wallet == nil || !e.wallet.IsConfigured() {
+		return nil, fmt.Errorf("live trading requires a configured wallet")
 	}
-	// TODO: Implement live trading
-	return nil, nil
+
+	var quote *quotes.Quote
+	var err error
+	if aggregator != "" {
+		quote, err = e.discovery.GetQuoteViaAggregator(ctx, "ethereum", aggregator, opportunity.Token.Address, amount)
+		if err != nil {
+			return nil, fmt.Errorf("aggregator %q quote failed: %w", aggregator, err)
+		}
+		e.logger.Info("routed trade through aggregator", zap.String("aggregator", aggregator), zap.Float64("amount_out", quote.AmountOut))
+	} else {
+		quote, err = e.discovery.GetQuote(ctx, "ethereum", quotes.NativeTokenAddress("ethereum"), opportunity.Token.Address, amount)
+		if err != nil {
+			return nil, fmt.Errorf("quote failed: %w", err)
+		}
+	}
+	if quote.To == "" || quote.Data == "" {
+		return nil, fmt.Errorf("quote from %s carries no swap calldata", quote.Source)
+	}
+
+	tx, err := contracts.PreflightAndSign(ctx, e.wallet, common.HexToAddress(quote.To), common.FromHex(quote.Data), weiFromETH(amount), e.config.GasLimit)
+	if err != nil {
+		trade := models.NewTrade(opportunity.Token.Address, opportunity.Token.Symbol, models.TradeTypeBuy, amount, opportunity.Token.Price, false)
+		trade.Status = models.TradeStatusFailed
+		return trade, fmt.Errorf("preflight failed: %w", err)
+	}
+
+	if err := e.submitLiveTransaction(ctx, tx); err != nil {
+		trade := models.NewTrade(opportunity.Token.Address, opportunity.Token.Symbol, models.TradeTypeBuy, amount, opportunity.Token.Price, false)
+		trade.Status = models.TradeStatusFailed
+		return trade, fmt.Errorf("submit transaction: %w", err)
+	}
+
+	trade := models.NewTrade(opportunity.Token.Address, opportunity.Token.Symbol, models.TradeTypeBuy, amount, opportunity.Token.Price, false)
+	trade.TxHash = tx.Hash().Hex()
+	e.mu.Lock()
+	e.totalTrades++
+	e.mu.Unlock()
+	return trade, nil
+}
+
+// privateSubmitRetries is how many submission attempts submitLiveTransaction
+// makes to the private relay before falling back to the public mempool.
+const privateSubmitRetries = 3
+
+// submitLiveTransaction sends tx through the configured private relay when
+// UsePrivateMempool is enabled, first simulating it with SimulateBundle to
+// abort on a revert, and falls back to the public mempool after
+// privateSubmitRetries failed submission attempts (or immediately if no
+// relay is configured).
+func (e *Engine) submitLiveTransaction(ctx context.Context, tx *types.Transaction) error {
+	if !e.config.UsePrivateMempool || e.config.PrivateRelayURL == "" {
+		return e.wallet.SendTransaction(ctx, tx)
+	}
+
+	blockNumber, err := e.wallet.Client().BlockNumber(ctx)
+	if err != nil {
+		e.logger.Warn("failed to fetch current block for bundle target, falling back to public mempool", zap.Error(err))
+		return e.wallet.SendTransaction(ctx, tx)
+	}
+	opts := wallet.BundleOpts{TargetBlock: blockNumber + 1}
+	bundle := []*types.Transaction{tx}
+
+	if _, err := e.wallet.SimulateBundle(ctx, bundle, opts); err != nil {
+		return fmt.Errorf("bundle simulation failed, aborting: %w", err)
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= privateSubmitRetries; attempt++ {
+		_, err := e.wallet.SendBundle(ctx, bundle, opts)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		e.logger.Warn("private bundle submission failed, retrying", zap.Int("attempt", attempt), zap.Error(err))
+	}
+
+	e.logger.Warn("private mempool submission exhausted retries, falling back to public mempool", zap.Error(lastErr))
+	return e.wallet.SendTransaction(ctx, tx)
 }
 
 func (e *Engine) runLoop(ctx context.Context) {
@@ -157,7 +313,7 @@ func (e *Engine) runLoop(ctx context.Context) {
 func (e *Engine) scan(ctx context.Context) {
 	e.logger.Debug("scanning for opportunities")
 
-	opportunities, err := e.discovery.FindOpportunities(ctx, "ethereum", e.config.MinLiquidity)
+	opportunities, err := e.discovery.FindOpportunities(ctx, "ethereum", e.config.MinLiquidity, e.config.TradeAmount)
 	if err != nil {
 		e.logger.Error("failed to find opportunities", zap.Error(err))
 		return
@@ -165,17 +321,28 @@ func (e *Engine) scan(ctx context.Context) {
 
 	e.mu.Lock()
 	e.opportunities = opportunities
+	recorder := e.recorder
 	e.mu.Unlock()
 
+	if recorder != nil {
+		recorder.record(time.Now(), opportunities)
+	}
+
 	if len(opportunities) > 0 {
 		e.logger.Info("found opportunities", zap.Int("count", len(opportunities)))
-		
+
+		if e.events != nil {
+			for _, opp := range opportunities {
+				e.events.Publish(events.TopicOpportunities, "opportunity_found", opp)
+			}
+		}
+
 		// Auto-execute in paper mode if enabled
 		if e.config.PaperTradingMode && len(opportunities) > 0 {
 			// Execute top opportunity
 			opp := opportunities[0]
 			if opp.ConfidenceScore > 0.6 {
-				trade, err := e.paper.ExecuteTrade(ctx, opp.Token, models.TradeTypeBuy, e.config.TradeAmount)
+				trade, err := e.paper.ExecuteTrade(ctx, opp.Token, models.TradeTypeBuy, e.config.TradeAmount, 1)
 				if err != nil {
 					e.logger.Error("paper trade failed", zap.Error(err))
 				} else {
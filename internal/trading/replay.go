@@ -0,0 +1,275 @@
+package trading
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/twadelij/cryptojackal/internal/models"
+	"github.com/twadelij/cryptojackal/internal/paper"
+	"go.uber.org/zap"
+)
+
+// ReplaySource produces recorded opportunities in chronological order for
+// Engine.Replay to step through. Next returns ok=false (with a nil error)
+// once the feed is exhausted.
+type ReplaySource interface {
+	Next(ctx context.Context) (timestamp time.Time, opportunities []models.TradingOpportunity, ok bool, err error)
+}
+
+// replayRecord is the on-the-wire shape both JSONLReplaySource and
+// RedisReplaySource decode: one recorded scan() result.
+type replayRecord struct {
+	Timestamp     time.Time                   `json:"timestamp"`
+	Opportunities []models.TradingOpportunity `json:"opportunities"`
+}
+
+// JSONLReplaySource replays opportunities recorded as JSON lines in a
+// file, one replayRecord per line in chronological order.
+type JSONLReplaySource struct {
+	file    *os.File
+	scanner *bufio.Scanner
+}
+
+// NewJSONLReplaySource opens path for replay. Callers should Close it
+// once the replay is done, including on error.
+func NewJSONLReplaySource(path string) (*JSONLReplaySource, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open replay file: %w", err)
+	}
+	return &JSONLReplaySource{file: f, scanner: bufio.NewScanner(f)}, nil
+}
+
+// Next implements ReplaySource.
+func (s *JSONLReplaySource) Next(ctx context.Context) (time.Time, []models.TradingOpportunity, bool, error) {
+	if !s.scanner.Scan() {
+		if err := s.scanner.Err(); err != nil {
+			return time.Time{}, nil, false, fmt.Errorf("read replay line: %w", err)
+		}
+		return time.Time{}, nil, false, nil
+	}
+	var record replayRecord
+	if err := json.Unmarshal(s.scanner.Bytes(), &record); err != nil {
+		return time.Time{}, nil, false, fmt.Errorf("decode replay line: %w", err)
+	}
+	return record.Timestamp, record.Opportunities, true, nil
+}
+
+// Close releases the underlying file.
+func (s *JSONLReplaySource) Close() error {
+	return s.file.Close()
+}
+
+// RedisStreamReader is the subset of a Redis stream client Engine.Replay
+// needs: read the next recorded entry in stream order. Callers adapt
+// their own Redis client (e.g. go-redis's XRead) to this interface, since
+// this package takes no Redis dependency of its own.
+type RedisStreamReader interface {
+	ReadNext(ctx context.Context) (payload []byte, ok bool, err error)
+}
+
+// RedisReplaySource replays opportunities from a Redis stream, decoding
+// each entry payload the same way JSONLReplaySource decodes a line.
+type RedisReplaySource struct {
+	reader RedisStreamReader
+}
+
+// NewRedisReplaySource returns a ReplaySource backed by reader.
+func NewRedisReplaySource(reader RedisStreamReader) *RedisReplaySource {
+	return &RedisReplaySource{reader: reader}
+}
+
+// Next implements ReplaySource.
+func (s *RedisReplaySource) Next(ctx context.Context) (time.Time, []models.TradingOpportunity, bool, error) {
+	payload, ok, err := s.reader.ReadNext(ctx)
+	if err != nil {
+		return time.Time{}, nil, false, fmt.Errorf("read redis stream: %w", err)
+	}
+	if !ok {
+		return time.Time{}, nil, false, nil
+	}
+	var record replayRecord
+	if err := json.Unmarshal(payload, &record); err != nil {
+		return time.Time{}, nil, false, fmt.Errorf("decode redis entry: %w", err)
+	}
+	return record.Timestamp, record.Opportunities, true, nil
+}
+
+// Recorder captures every live scan() result in order, so a run can be
+// replayed later via Engine.Replay without a file or Redis round-trip.
+// Engine feeds it from scan() once wired in with SetRecorder; the zero
+// value (via NewRecorder) is ready to use as a ReplaySource as soon as
+// recording starts.
+type Recorder struct {
+	mu      sync.Mutex
+	entries []replayRecord
+	pos     int
+}
+
+// NewRecorder returns an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// record appends a scan result. Called by Engine.scan when a Recorder is
+// configured via SetRecorder.
+func (r *Recorder) record(timestamp time.Time, opportunities []models.TradingOpportunity) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, replayRecord{Timestamp: timestamp, Opportunities: opportunities})
+}
+
+// Next implements ReplaySource, replaying captured entries in the order
+// they were recorded.
+func (r *Recorder) Next(ctx context.Context) (time.Time, []models.TradingOpportunity, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pos >= len(r.entries) {
+		return time.Time{}, nil, false, nil
+	}
+	entry := r.entries[r.pos]
+	r.pos++
+	return entry.Timestamp, entry.Opportunities, true, nil
+}
+
+// defaultReplayConfidenceThreshold mirrors the auto-execute threshold
+// scan() uses for live paper trading.
+const defaultReplayConfidenceThreshold = 0.6
+
+// ReplayOpts configures Engine.Replay. A zero value replays with the
+// engine's configured TradeAmount and InitialBalance, and the same 0.6
+// confidence threshold scan() uses for auto-execution.
+type ReplayOpts struct {
+	ConfidenceThreshold float64
+	TradeAmount         float64
+	InitialBalance      float64
+}
+
+// ReplayResult aggregates the outcome of a replay run.
+type ReplayResult struct {
+	TotalTrades     int            `json:"total_trades"`
+	WinRate         float64        `json:"win_rate"`
+	TotalProfitLoss float64        `json:"total_profit_loss"`
+	MaxDrawdown     float64        `json:"max_drawdown"`
+	Trades          []models.Trade `json:"trades"`
+}
+
+// Replay steps through feed in chronological order, applying the same
+// confidence-threshold logic scan() uses, but as a round trip rather than
+// scan()'s buy-and-hold: it buys when a token has no open position and
+// the opportunity clears threshold, and sells the full position the
+// first time a held token's opportunity drops back to or below
+// threshold, so PnL is actually realized instead of staying unrealized
+// for the life of the replay. Any position still open when feed is
+// exhausted is closed at its last-seen price so a feed that ends
+// mid-position doesn't just drop its PnL on the floor. Trading runs
+// against a sandboxed paper.Service seeded with InitialBalance and a
+// virtual clock pinned to each entry's recorded timestamp, so replay
+// trades never touch the engine's live paper portfolio or trade history
+// and results are reproducible regardless of when Replay is called.
+func (e *Engine) Replay(ctx context.Context, feed ReplaySource, opts ReplayOpts) (*ReplayResult, error) {
+	threshold := opts.ConfidenceThreshold
+	if threshold <= 0 {
+		threshold = defaultReplayConfidenceThreshold
+	}
+	amount := opts.TradeAmount
+	if amount <= 0 {
+		amount = e.config.TradeAmount
+	}
+	initialBalance := opts.InitialBalance
+	if initialBalance <= 0 {
+		initialBalance = e.config.InitialBalance
+	}
+
+	clock := paper.NewMockClock(time.Now())
+	sandbox := paper.NewService(initialBalance, nil, nil, paper.MarginConfig{}, nil, clock, nil, e.logger)
+
+	result := &ReplayResult{}
+	peakValue := sandbox.GetPortfolio().TotalValue
+	lastSeen := make(map[string]models.Token)
+
+	recordTrade := func(trade *models.Trade) {
+		result.Trades = append(result.Trades, *trade)
+		if value := sandbox.GetPortfolio().TotalValue; value > peakValue {
+			peakValue = value
+		} else if peakValue > 0 {
+			if dd := (peakValue - value) / peakValue; dd > result.MaxDrawdown {
+				result.MaxDrawdown = dd
+			}
+		}
+	}
+
+	for {
+		timestamp, opportunities, ok, err := feed.Next(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("read replay feed: %w", err)
+		}
+		if !ok {
+			break
+		}
+		clock.Set(timestamp)
+
+		if len(opportunities) == 0 {
+			continue
+		}
+		opp := opportunities[0]
+		lastSeen[opp.Token.Address] = opp.Token
+
+		holding := sandbox.GetPortfolio().TokenBalances[opp.Token.Address]
+		switch {
+		case holding.Balance <= 0 && opp.ConfidenceScore > threshold:
+			trade, err := sandbox.ExecuteTrade(ctx, opp.Token, models.TradeTypeBuy, amount, 1)
+			if err != nil {
+				e.logger.Warn("replay: buy failed, continuing", zap.String("token", opp.Token.Symbol), zap.Error(err))
+				continue
+			}
+			recordTrade(trade)
+
+		case holding.Balance > 0 && opp.ConfidenceScore <= threshold:
+			trade, err := sandbox.ExecuteTrade(ctx, opp.Token, models.TradeTypeSell, holding.Balance, 1)
+			if err != nil {
+				e.logger.Warn("replay: sell failed, continuing", zap.String("token", opp.Token.Symbol), zap.Error(err))
+				continue
+			}
+			recordTrade(trade)
+		}
+	}
+
+	// Close out any position still open at feed exhaustion so its PnL is
+	// realized instead of permanently unrealized.
+	for address, token := range lastSeen {
+		holding := sandbox.GetPortfolio().TokenBalances[address]
+		if holding.Balance <= 0 {
+			continue
+		}
+		trade, err := sandbox.ExecuteTrade(ctx, token, models.TradeTypeSell, holding.Balance, 1)
+		if err != nil {
+			e.logger.Warn("replay: final close failed", zap.String("token", token.Symbol), zap.Error(err))
+			continue
+		}
+		recordTrade(trade)
+	}
+
+	result.TotalTrades = len(result.Trades)
+
+	var sellCount, winCount int
+	for _, t := range result.Trades {
+		if t.Type != models.TradeTypeSell {
+			continue
+		}
+		sellCount++
+		result.TotalProfitLoss += t.ProfitLoss
+		if t.ProfitLoss > 0 {
+			winCount++
+		}
+	}
+	if sellCount > 0 {
+		result.WinRate = float64(winCount) / float64(sellCount)
+	}
+	return result, nil
+}
@@ -0,0 +1,145 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// BundleOpts configures a private bundle submission or simulation.
+type BundleOpts struct {
+	// TargetBlock is the block number the bundle should land in.
+	TargetBlock uint64
+	// MinTimestamp and MaxTimestamp, if non-zero, bound the unix timestamps
+	// the bundle is valid for.
+	MinTimestamp int64
+	MaxTimestamp int64
+}
+
+type jsonRPCRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type jsonRPCResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// SendBundle submits txs as a single atomic bundle to the configured
+// private relay (e.g. Flashbots Protect, bloXroute, MEV-Share) via
+// eth_sendBundle, bypassing the public mempool entirely. It returns the
+// relay's bundle hash.
+func (w *Wallet) SendBundle(ctx context.Context, txs []*types.Transaction, opts BundleOpts) (string, error) {
+	result, err := w.callRelay(ctx, "eth_sendBundle", bundleParams(txs, opts))
+	if err != nil {
+		return "", err
+	}
+
+	var parsed struct {
+		BundleHash string `json:"bundleHash"`
+	}
+	if err := json.Unmarshal(result, &parsed); err != nil {
+		return "", fmt.Errorf("decode eth_sendBundle response: %w", err)
+	}
+	return parsed.BundleHash, nil
+}
+
+// SimulateBundle runs txs against the relay's eth_callBundle simulator so
+// the caller can detect a revert before actually submitting the bundle.
+func (w *Wallet) SimulateBundle(ctx context.Context, txs []*types.Transaction, opts BundleOpts) (json.RawMessage, error) {
+	return w.callRelay(ctx, "eth_callBundle", bundleParams(txs, opts))
+}
+
+func bundleParams(txs []*types.Transaction, opts BundleOpts) []interface{} {
+	rawTxs := make([]string, len(txs))
+	for i, tx := range txs {
+		raw, _ := tx.MarshalBinary()
+		rawTxs[i] = "0x" + hex.EncodeToString(raw)
+	}
+
+	params := map[string]interface{}{
+		"txs":         rawTxs,
+		"blockNumber": fmt.Sprintf("0x%x", opts.TargetBlock),
+	}
+	if opts.MinTimestamp > 0 {
+		params["minTimestamp"] = opts.MinTimestamp
+	}
+	if opts.MaxTimestamp > 0 {
+		params["maxTimestamp"] = opts.MaxTimestamp
+	}
+	return []interface{}{params}
+}
+
+// callRelay signs body with the wallet key per the Flashbots convention
+// (X-Flashbots-Signature: address:hex(sign(eip191(keccak256(body))))) and
+// posts it to the configured relay endpoint.
+func (w *Wallet) callRelay(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+	if w.relayURL == "" {
+		return nil, fmt.Errorf("no private relay configured")
+	}
+	if w.privateKey == nil {
+		return nil, fmt.Errorf("no private key configured")
+	}
+
+	body, err := json.Marshal(jsonRPCRequest{JSONRPC: "2.0", ID: 1, Method: method, Params: params})
+	if err != nil {
+		return nil, fmt.Errorf("marshal relay request: %w", err)
+	}
+
+	sig, err := w.signRelayBody(body)
+	if err != nil {
+		return nil, fmt.Errorf("sign relay request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.relayURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("build relay request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Flashbots-Signature", sig)
+	if w.relayAuthKey != "" {
+		req.Header.Set("Authorization", "Bearer "+w.relayAuthKey)
+	}
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("relay request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var rpcResp jsonRPCResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return nil, fmt.Errorf("decode relay response: %w", err)
+	}
+	if rpcResp.Error != nil {
+		return nil, fmt.Errorf("relay error %d: %s", rpcResp.Error.Code, rpcResp.Error.Message)
+	}
+	return rpcResp.Result, nil
+}
+
+// signRelayBody produces the "address:hex(signature)" header Flashbots-style
+// relays expect: it signs the EIP-191 personal_sign digest of
+// keccak256(body), not the raw hash, since that's what relays verify
+// X-Flashbots-Signature against.
+func (w *Wallet) signRelayBody(body []byte) (string, error) {
+	hash := crypto.Keccak256Hash(body)
+	digest := accounts.TextHash(hash.Bytes())
+	sig, err := crypto.Sign(digest, w.privateKey)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s:0x%s", w.address.Hex(), hex.EncodeToString(sig)), nil
+}
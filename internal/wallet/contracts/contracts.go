@@ -0,0 +1,120 @@
+// Package contracts provides minimal, hand-rolled bindings for the
+// ERC-20 and Uniswap router calls the trading engine needs to execute a
+// live swap, without depending on abigen-generated code. Every
+// state-changing call is preflighted against the pending block first so a
+// revert costs nothing but an eth_call.
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Backend is the subset of wallet.Wallet behavior this package needs:
+// reading contract state to preflight a call, and signing the resulting
+// transaction — preferring an EIP-1559 signer but falling back to a
+// legacy one on chains SuggestFees reports don't support it. Submission
+// is left to the caller — ERC20 and Router submit it themselves via
+// SendTransaction, but trading.Engine calls PreflightAndSign directly so
+// it can route submission through its own private-mempool logic instead.
+type Backend interface {
+	Address() common.Address
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+	HasCode(ctx context.Context, addr common.Address, pending bool) (bool, error)
+	EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error)
+	SuggestFees(ctx context.Context) (tipCap, feeCap *big.Int, err error)
+	SignDynamicFeeTx(ctx context.Context, to common.Address, data []byte, value *big.Int, gasLimit uint64, tipCap, feeCap *big.Int) (*types.Transaction, error)
+	GetGasPrice(ctx context.Context) (*big.Int, error)
+	GetNonce(ctx context.Context) (uint64, error)
+	SignTransaction(tx *types.Transaction) (*types.Transaction, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
+// PreflightError reports that a simulated call against the pending block
+// would revert, along with the decoded Solidity revert reason and a
+// best-effort gas estimate for the would-be transaction.
+type PreflightError struct {
+	Reason  string
+	GasUsed uint64
+}
+
+func (e *PreflightError) Error() string {
+	return fmt.Sprintf("contract call would revert: %s", e.Reason)
+}
+
+// PreflightAndSign simulates a call to "to" against the pending block to
+// detect a revert before it costs any gas, then signs (but does not
+// submit) the real transaction, as EIP-1559 where backend's SuggestFees
+// succeeds and as a legacy transaction otherwise.
+func PreflightAndSign(ctx context.Context, backend Backend, to common.Address, data []byte, value *big.Int, gasLimit uint64) (*types.Transaction, error) {
+	hasCode, err := backend.HasCode(ctx, to, true)
+	if err != nil {
+		return nil, fmt.Errorf("check contract code: %w", err)
+	}
+	if !hasCode {
+		return nil, fmt.Errorf("no contract code at %s (missing bytecode or chain hasn't caught up)", to.Hex())
+	}
+
+	call := ethereum.CallMsg{From: backend.Address(), To: &to, Data: data, Value: value}
+	if _, err := backend.CallContract(ctx, call, nil); err != nil {
+		gasUsed, _ := backend.EstimateGas(ctx, call)
+		return nil, &PreflightError{Reason: decodeRevertReason(err), GasUsed: gasUsed}
+	}
+
+	return signTransaction(ctx, backend, to, data, value, gasLimit)
+}
+
+// signTransaction builds and signs a transaction to to, preferring an
+// EIP-1559 dynamic fee transaction and falling back to a legacy one on
+// chains that don't support it (SuggestFees returns an error in that
+// case).
+func signTransaction(ctx context.Context, backend Backend, to common.Address, data []byte, value *big.Int, gasLimit uint64) (*types.Transaction, error) {
+	tipCap, feeCap, err := backend.SuggestFees(ctx)
+	if err == nil {
+		tx, err := backend.SignDynamicFeeTx(ctx, to, data, value, gasLimit, tipCap, feeCap)
+		if err != nil {
+			return nil, fmt.Errorf("sign transaction: %w", err)
+		}
+		return tx, nil
+	}
+
+	gasPrice, gasErr := backend.GetGasPrice(ctx)
+	if gasErr != nil {
+		return nil, fmt.Errorf("suggest legacy gas price: %w", gasErr)
+	}
+	nonce, nonceErr := backend.GetNonce(ctx)
+	if nonceErr != nil {
+		return nil, fmt.Errorf("get nonce: %w", nonceErr)
+	}
+	tx, err := backend.SignTransaction(types.NewTransaction(nonce, to, value, gasLimit, gasPrice, data))
+	if err != nil {
+		return nil, fmt.Errorf("sign transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// decodeRevertReason extracts the Solidity revert string from a
+// CallContract error, falling back to the raw error message when the node
+// doesn't format it as "execution reverted: <reason>".
+func decodeRevertReason(err error) string {
+	const marker = "execution reverted: "
+	msg := err.Error()
+	if idx := strings.Index(msg, marker); idx >= 0 {
+		return msg[idx+len(marker):]
+	}
+	return msg
+}
+
+func packAddress(addr common.Address) []byte {
+	return common.LeftPadBytes(addr.Bytes(), 32)
+}
+
+func packUint256(v *big.Int) []byte {
+	return common.LeftPadBytes(v.Bytes(), 32)
+}
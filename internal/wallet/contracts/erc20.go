@@ -0,0 +1,74 @@
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	selectorBalanceOf = crypto.Keccak256([]byte("balanceOf(address)"))[:4]
+	selectorAllowance = crypto.Keccak256([]byte("allowance(address,address)"))[:4]
+	selectorApprove   = crypto.Keccak256([]byte("approve(address,uint256)"))[:4]
+	selectorTransfer  = crypto.Keccak256([]byte("transfer(address,uint256)"))[:4]
+)
+
+// ERC20 is a minimal binding for the standard ERC-20 methods the trading
+// engine needs.
+type ERC20 struct {
+	backend Backend
+	address common.Address
+}
+
+// NewERC20 returns an ERC20 binding for the token deployed at address.
+func NewERC20(backend Backend, address common.Address) *ERC20 {
+	return &ERC20{backend: backend, address: address}
+}
+
+// BalanceOf returns owner's token balance.
+func (t *ERC20) BalanceOf(ctx context.Context, owner common.Address) (*big.Int, error) {
+	data := append(append([]byte{}, selectorBalanceOf...), packAddress(owner)...)
+	result, err := t.backend.CallContract(ctx, ethereum.CallMsg{To: &t.address, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("balanceOf: %w", err)
+	}
+	return new(big.Int).SetBytes(result), nil
+}
+
+// Allowance returns how much spender may transfer out of owner's balance.
+func (t *ERC20) Allowance(ctx context.Context, owner, spender common.Address) (*big.Int, error) {
+	data := append(append([]byte{}, selectorAllowance...), append(packAddress(owner), packAddress(spender)...)...)
+	result, err := t.backend.CallContract(ctx, ethereum.CallMsg{To: &t.address, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("allowance: %w", err)
+	}
+	return new(big.Int).SetBytes(result), nil
+}
+
+// Approve preflights and submits an approve(spender, amount) call.
+func (t *ERC20) Approve(ctx context.Context, spender common.Address, amount *big.Int, gasLimit uint64) (*types.Transaction, error) {
+	data := append(append([]byte{}, selectorApprove...), append(packAddress(spender), packUint256(amount)...)...)
+	return t.sendCall(ctx, data, gasLimit)
+}
+
+// Transfer preflights and submits a transfer(to, amount) call.
+func (t *ERC20) Transfer(ctx context.Context, to common.Address, amount *big.Int, gasLimit uint64) (*types.Transaction, error) {
+	data := append(append([]byte{}, selectorTransfer...), append(packAddress(to), packUint256(amount)...)...)
+	return t.sendCall(ctx, data, gasLimit)
+}
+
+func (t *ERC20) sendCall(ctx context.Context, data []byte, gasLimit uint64) (*types.Transaction, error) {
+	tx, err := PreflightAndSign(ctx, t.backend, t.address, data, big.NewInt(0), gasLimit)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.backend.SendTransaction(ctx, tx); err != nil {
+		return nil, fmt.Errorf("send transaction: %w", err)
+	}
+	return tx, nil
+}
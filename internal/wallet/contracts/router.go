@@ -0,0 +1,98 @@
+package contracts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	selectorSwapExactETHForTokens = crypto.Keccak256([]byte("swapExactETHForTokens(uint256,address[],address,uint256)"))[:4]
+	selectorExactInputSingle      = crypto.Keccak256([]byte("exactInputSingle((address,address,uint24,address,uint256,uint256,uint256,uint160))"))[:4]
+)
+
+// Router is a minimal binding for the Uniswap V2 and V3 router methods
+// the trading engine needs.
+type Router struct {
+	backend Backend
+	address common.Address
+}
+
+// NewRouter returns a Router binding for the router deployed at address.
+func NewRouter(backend Backend, address common.Address) *Router {
+	return &Router{backend: backend, address: address}
+}
+
+// SwapExactETHForTokens preflights and submits a Uniswap V2
+// swapExactETHForTokens(amountOutMin, path, to, deadline) call, sending
+// amountIn wei of native currency along with it.
+func (r *Router) SwapExactETHForTokens(ctx context.Context, amountIn, amountOutMin *big.Int, path []common.Address, to common.Address, deadline *big.Int, gasLimit uint64) (*types.Transaction, error) {
+	data := encodeSwapExactETHForTokens(amountOutMin, path, to, deadline)
+	return r.sendCall(ctx, data, amountIn, gasLimit)
+}
+
+func encodeSwapExactETHForTokens(amountOutMin *big.Int, path []common.Address, to common.Address, deadline *big.Int) []byte {
+	var buf bytes.Buffer
+	buf.Write(selectorSwapExactETHForTokens)
+	buf.Write(packUint256(amountOutMin))
+	buf.Write(packUint256(big.NewInt(4 * 32))) // offset to the dynamic path array
+	buf.Write(packAddress(to))
+	buf.Write(packUint256(deadline))
+	buf.Write(packUint256(big.NewInt(int64(len(path)))))
+	for _, addr := range path {
+		buf.Write(packAddress(addr))
+	}
+	return buf.Bytes()
+}
+
+// ExactInputSingleParams mirrors Uniswap V3's
+// ISwapRouter.ExactInputSingleParams. Fee and SqrtPriceLimitX96 are
+// uint24/uint160 on-chain but passed as *big.Int here since Go has no
+// native sub-word integer types to match them.
+type ExactInputSingleParams struct {
+	TokenIn           common.Address
+	TokenOut          common.Address
+	Fee               *big.Int
+	Recipient         common.Address
+	Deadline          *big.Int
+	AmountIn          *big.Int
+	AmountOutMinimum  *big.Int
+	SqrtPriceLimitX96 *big.Int
+}
+
+// ExactInputSingle preflights and submits a Uniswap V3
+// exactInputSingle(params) call.
+func (r *Router) ExactInputSingle(ctx context.Context, params ExactInputSingleParams, gasLimit uint64) (*types.Transaction, error) {
+	data := encodeExactInputSingle(params)
+	return r.sendCall(ctx, data, big.NewInt(0), gasLimit)
+}
+
+func encodeExactInputSingle(p ExactInputSingleParams) []byte {
+	var buf bytes.Buffer
+	buf.Write(selectorExactInputSingle)
+	buf.Write(packAddress(p.TokenIn))
+	buf.Write(packAddress(p.TokenOut))
+	buf.Write(packUint256(p.Fee))
+	buf.Write(packAddress(p.Recipient))
+	buf.Write(packUint256(p.Deadline))
+	buf.Write(packUint256(p.AmountIn))
+	buf.Write(packUint256(p.AmountOutMinimum))
+	buf.Write(packUint256(p.SqrtPriceLimitX96))
+	return buf.Bytes()
+}
+
+func (r *Router) sendCall(ctx context.Context, data []byte, value *big.Int, gasLimit uint64) (*types.Transaction, error) {
+	tx, err := PreflightAndSign(ctx, r.backend, r.address, data, value, gasLimit)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.backend.SendTransaction(ctx, tx); err != nil {
+		return nil, fmt.Errorf("send transaction: %w", err)
+	}
+	return tx, nil
+}
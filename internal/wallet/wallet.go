@@ -5,7 +5,10 @@ import (
 	"crypto/ecdsa"
 	"fmt"
 	"math/big"
+	"net/http"
+	"time"
 
+	ethereum "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -15,15 +18,22 @@ import (
 
 // Wallet manages Ethereum wallet operations
 type Wallet struct {
-	client     *ethclient.Client
-	privateKey *ecdsa.PrivateKey
-	address    common.Address
-	chainID    *big.Int
-	logger     *zap.Logger
+	client       *ethclient.Client
+	privateKey   *ecdsa.PrivateKey
+	address      common.Address
+	chainID      *big.Int
+	maxGasPrice  *big.Int
+	httpClient   *http.Client
+	relayURL     string
+	relayAuthKey string
+	logger       *zap.Logger
 }
 
-// New creates a new wallet instance
-func New(nodeURL string, privateKeyHex string, chainID int64, logger *zap.Logger) (*Wallet, error) {
+// New creates a new wallet instance. maxGasPriceGwei caps the fee cap
+// SuggestFees will return; pass 0 to leave it unbounded. relayURL and
+// relayAuthKey configure the private-mempool submission path (SendBundle,
+// SimulateBundle); leave relayURL empty to disable it.
+func New(nodeURL string, privateKeyHex string, chainID int64, maxGasPriceGwei uint64, relayURL, relayAuthKey string, logger *zap.Logger) (*Wallet, error) {
 	client, err := ethclient.Dial(nodeURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to Ethereum node: %w", err)
@@ -45,12 +55,21 @@ func New(nodeURL string, privateKeyHex string, chainID int64, logger *zap.Logger
 		address = crypto.PubkeyToAddress(*publicKeyECDSA)
 	}
 
+	var maxGasPrice *big.Int
+	if maxGasPriceGwei > 0 {
+		maxGasPrice = new(big.Int).Mul(new(big.Int).SetUint64(maxGasPriceGwei), big.NewInt(1e9))
+	}
+
 	return &Wallet{
-		client:     client,
-		privateKey: privateKey,
-		address:    address,
-		chainID:    big.NewInt(chainID),
-		logger:     logger,
+		client:       client,
+		privateKey:   privateKey,
+		address:      address,
+		chainID:      big.NewInt(chainID),
+		maxGasPrice:  maxGasPrice,
+		httpClient:   &http.Client{Timeout: 10 * time.Second},
+		relayURL:     relayURL,
+		relayAuthKey: relayAuthKey,
+		logger:       logger,
 	}, nil
 }
 
@@ -87,7 +106,39 @@ func (w *Wallet) GetGasPrice(ctx context.Context) (*big.Int, error) {
 	return w.client.SuggestGasPrice(ctx)
 }
 
-// SignTransaction signs a transaction
+// CallContract simulates call, using the pending block when blockNumber
+// is nil. This is what the wallet/contracts package uses to preflight a
+// transaction before submitting it.
+func (w *Wallet) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if blockNumber == nil {
+		return w.client.PendingCallContract(ctx, call)
+	}
+	return w.client.CallContract(ctx, call, blockNumber)
+}
+
+// HasCode reports whether addr has contract bytecode deployed, checking
+// pending state when pending is true. This distinguishes "no contract
+// here" from "the node just hasn't caught up yet".
+func (w *Wallet) HasCode(ctx context.Context, addr common.Address, pending bool) (bool, error) {
+	var code []byte
+	var err error
+	if pending {
+		code, err = w.client.PendingCodeAt(ctx, addr)
+	} else {
+		code, err = w.client.CodeAt(ctx, addr, nil)
+	}
+	if err != nil {
+		return false, err
+	}
+	return len(code) > 0, nil
+}
+
+// EstimateGas estimates the gas a call would consume.
+func (w *Wallet) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return w.client.EstimateGas(ctx, call)
+}
+
+// SignTransaction signs a legacy (Type 0) transaction.
 func (w *Wallet) SignTransaction(tx *types.Transaction) (*types.Transaction, error) {
 	if w.privateKey == nil {
 		return nil, fmt.Errorf("no private key configured")
@@ -95,6 +146,62 @@ func (w *Wallet) SignTransaction(tx *types.Transaction) (*types.Transaction, err
 	return types.SignTx(tx, types.NewEIP155Signer(w.chainID), w.privateKey)
 }
 
+// SuggestFees returns a (tipCap, feeCap) pair for an EIP-1559 transaction:
+// tipCap comes straight from the node's SuggestGasTipCap, and feeCap is
+// 2*baseFee+tipCap, the margin go-ethereum itself recommends to survive a
+// couple of base-fee increases before the transaction is included. The
+// result is clamped to the wallet's configured max gas price, if any.
+func (w *Wallet) SuggestFees(ctx context.Context) (tipCap, feeCap *big.Int, err error) {
+	tipCap, err = w.client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("suggest gas tip cap: %w", err)
+	}
+
+	header, err := w.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetch latest header: %w", err)
+	}
+	if header.BaseFee == nil {
+		return nil, nil, fmt.Errorf("chain %s does not support EIP-1559", w.chainID)
+	}
+
+	feeCap = new(big.Int).Add(new(big.Int).Mul(header.BaseFee, big.NewInt(2)), tipCap)
+	if w.maxGasPrice != nil && feeCap.Cmp(w.maxGasPrice) > 0 {
+		feeCap = new(big.Int).Set(w.maxGasPrice)
+		if tipCap.Cmp(feeCap) > 0 {
+			tipCap = new(big.Int).Set(feeCap)
+		}
+	}
+	return tipCap, feeCap, nil
+}
+
+// SignDynamicFeeTx builds and signs an EIP-1559 (Type 2) transaction to
+// to, carrying value and data, using tipCap/feeCap from SuggestFees (or
+// caller-supplied values).
+func (w *Wallet) SignDynamicFeeTx(ctx context.Context, to common.Address, data []byte, value *big.Int, gasLimit uint64, tipCap, feeCap *big.Int) (*types.Transaction, error) {
+	if w.privateKey == nil {
+		return nil, fmt.Errorf("no private key configured")
+	}
+
+	nonce, err := w.GetNonce(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get nonce: %w", err)
+	}
+
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   w.chainID,
+		Nonce:     nonce,
+		GasTipCap: tipCap,
+		GasFeeCap: feeCap,
+		Gas:       gasLimit,
+		To:        &to,
+		Value:     value,
+		Data:      data,
+	})
+
+	return types.SignTx(tx, types.LatestSignerForChainID(w.chainID), w.privateKey)
+}
+
 // SendTransaction sends a signed transaction
 func (w *Wallet) SendTransaction(ctx context.Context, tx *types.Transaction) error {
 	return w.client.SendTransaction(ctx, tx)